@@ -5,18 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"git-gui/backend/git"
+	"git-gui/backend/graph"
 	"git-gui/backend/types"
 )
 
 // App is the main application struct.
 type App struct {
-	ctx         context.Context
-	executor    git.GitExecutor
-	repo        *types.GitRepo
-	initialPath string
+	ctx          context.Context
+	executor     git.GitExecutor
+	repo         *types.GitRepo
+	initialPath  string
+	branchPolicy types.BranchNamePolicy
+	diffOptions  types.DiffOptions
+	journal      *journal
+	graph        *graph.Layout
 }
 
 // NewApp creates a new App application struct.
@@ -32,6 +38,22 @@ func NewTestApp(executor git.GitExecutor, repo *types.GitRepo) *App {
 	}
 }
 
+// NewJournaledTestApp creates an App for testing that records mutating
+// calls in its operation journal.
+func NewJournaledTestApp(executor git.GitExecutor, repo *types.GitRepo) *App {
+	app := NewTestApp(executor, repo)
+	app.journal = &journal{}
+	return app
+}
+
+// NewSavedJournalTestApp creates an App for testing whose operation journal
+// is loaded from and saved to the given git directory.
+func NewSavedJournalTestApp(executor git.GitExecutor, repo *types.GitRepo, gitDir string) *App {
+	app := NewTestApp(executor, repo)
+	app.journal = loadJournal(gitDir)
+	return app
+}
+
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods.
 func (a *App) startup(ctx context.Context) {
@@ -61,10 +83,12 @@ func (a *App) InitRepo(path string) error {
 	repoPath := strings.TrimSpace(root)
 	a.executor = git.NewGitExecutor(repoPath)
 	a.repo = &types.GitRepo{Path: repoPath}
+	a.journal = &journal{}
+	a.graph = nil
+	a.refreshHead()
 
-	branch, err := a.GetCurrentBranch()
-	if err == nil {
-		a.repo.CurrentBranch = branch
+	if gitDir, err := a.gitDir(); err == nil {
+		a.journal = loadJournal(gitDir)
 	}
 
 	return nil
@@ -117,32 +141,29 @@ func (a *App) GetGitStatus() ([]types.FileStatus, error) {
 	return files, nil
 }
 
-// GetGitDiff returns the diff for a specific file.
+// GetGitDiff returns the diff for a specific file. When the file has no
+// unstaged changes its staged diff is returned instead; use GetDiff to pick
+// the compared sides explicitly.
 func (a *App) GetGitDiff(filePath string) (*types.DiffResult, error) {
 	if a.executor == nil {
 		return nil, errors.New("no repository initialized")
 	}
 
 	// Try unstaged diff first
-	output, err := a.executor.Execute("diff", filePath)
+	output, err := a.executor.Execute(a.diffCommand(filePath)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get diff for %s: %w", filePath, err)
 	}
 
 	// If no unstaged diff, try staged diff
 	if strings.TrimSpace(output) == "" {
-		output, err = a.executor.Execute("diff", "--cached", filePath)
+		output, err = a.executor.Execute(a.diffCommand("--cached", filePath)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get staged diff for %s: %w", filePath, err)
 		}
 	}
 
-	result, err := git.ParseDiff(filePath, output)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse diff for %s: %w", filePath, err)
-	}
-
-	return result, nil
+	return a.buildDiffResult(filePath, output, true)
 }
 
 // GetBranches returns all local branches.
@@ -178,37 +199,80 @@ func (a *App) GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(output), nil
 }
 
-// SwitchBranch switches to the specified branch.
-func (a *App) SwitchBranch(name string) error {
+// GetHeadState inspects the repository state files to describe HEAD and any
+// operation in progress, refreshing the cached repo info along the way.
+func (a *App) GetHeadState() (*types.HeadState, error) {
 	if a.executor == nil {
-		return errors.New("no repository initialized")
+		return nil, errors.New("no repository initialized")
 	}
 
-	_, err := a.executor.Execute("checkout", name)
+	gitDir, err := a.gitDir()
 	if err != nil {
-		return fmt.Errorf("failed to switch to branch %s: %w", name, err)
+		return nil, err
 	}
 
-	a.repo.CurrentBranch = name
-	return nil
+	state, err := git.ReadHeadState(gitDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD state: %w", err)
+	}
+
+	a.repo.HeadState = *state
+	if state.Detached {
+		a.repo.CurrentBranch = ""
+	} else {
+		a.repo.CurrentBranch = state.Branch
+	}
+
+	return state, nil
 }
 
-// CreateBranch creates a new branch and switches to it.
-func (a *App) CreateBranch(name string) error {
-	if a.executor == nil {
-		return errors.New("no repository initialized")
+// refreshHead updates the cached HEAD state after HEAD may have moved. When
+// the state files cannot be read only the current branch name is refreshed.
+func (a *App) refreshHead() {
+	if _, err := a.GetHeadState(); err != nil {
+		if branch, err := a.GetCurrentBranch(); err == nil {
+			a.repo.CurrentBranch = branch
+		}
 	}
+}
 
-	_, err := a.executor.Execute("checkout", "-b", name)
+// gitDir returns the absolute path of the repository's git directory.
+func (a *App) gitDir() (string, error) {
+	output, err := a.executor.Execute("rev-parse", "--absolute-git-dir")
 	if err != nil {
-		return fmt.Errorf("failed to create branch %s: %w", name, err)
+		return "", fmt.Errorf("failed to find git directory: %w", err)
 	}
+	return strings.TrimSpace(output), nil
+}
 
-	a.repo.CurrentBranch = name
-	return nil
+// SwitchBranch switches to the specified branch.
+func (a *App) SwitchBranch(name string) error {
+	if a.executor == nil {
+		return errors.New("no repository initialized")
+	}
+
+	return a.journaled("switch branch", nil, func() error {
+		_, err := a.executor.Execute("checkout", name)
+		if err != nil {
+			return fmt.Errorf("failed to switch to branch %s: %w", name, err)
+		}
+
+		a.refreshHead()
+		return nil
+	})
 }
 
-// CommitFiles stages the specified files and creates a commit.
+// CreateBranch creates a new branch from HEAD and switches to it.
+func (a *App) CreateBranch(name string) error {
+	return a.CreateBranchFrom(name, "", true, false)
+}
+
+// CommitFiles commits exactly the specified files as they are in the working
+// tree, including deletions and the old path of a staged rename. Other
+// changes already staged are left in the index and are not part of the
+// commit, except while a merge, cherry-pick or revert is being concluded:
+// git records those from the whole index, so the files are staged and the
+// index is committed.
 func (a *App) CommitFiles(files []string, message string) (*types.CommitResult, error) {
 	if a.executor == nil {
 		return nil, errors.New("no repository initialized")
@@ -220,26 +284,95 @@ func (a *App) CommitFiles(files []string, message string) (*types.CommitResult,
 		return nil, errors.New("commit message required")
 	}
 
-	// Stage files
-	args := append([]string{"add"}, files...)
-	_, err := a.executor.Execute(args...)
+	var result *types.CommitResult
+	err := a.journaled("commit", []string{"HEAD"}, func() error {
+		// Stage files first so that untracked files are known to commit --only
+		if err := a.StageFiles(files); err != nil {
+			return err
+		}
+
+		state, err := a.GetHeadState()
+		if err != nil {
+			return err
+		}
+		switch state.Operation {
+		case types.OperationMerge, types.OperationCherryPick, types.OperationRevert:
+			result, err = a.commit(message)
+			return err
+		}
+
+		only, err := a.withRenameSources(files)
+		if err != nil {
+			return err
+		}
+		result, err = a.commit(message, only...)
+		return err
+	})
+	return result, err
+}
+
+// withRenameSources adds the old path of every staged rename whose new path
+// is in files, so that committing the new path also commits the deletion.
+func (a *App) withRenameSources(files []string) ([]string, error) {
+	output, err := a.executor.Execute("diff", "--cached", "--name-status", "-z", "-M")
 	if err != nil {
-		return nil, fmt.Errorf("failed to stage files: %w", err)
+		return nil, fmt.Errorf("failed to list staged renames: %w", err)
 	}
 
-	// Commit
-	output, err := a.executor.Execute("commit", "-m", message)
+	paths := slices.Clone(files)
+	for _, change := range git.ParseNameStatus(output) {
+		if change.Status == types.StatusRenamed && slices.Contains(files, change.Path) && !slices.Contains(paths, change.OldPath) {
+			paths = append(paths, change.OldPath)
+		}
+	}
+	return paths, nil
+}
+
+// CommitIndex commits exactly what is currently staged, without adding any
+// files, so incrementally staged changes are committed as reviewed.
+func (a *App) CommitIndex(message string) (*types.CommitResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if message == "" {
+		return nil, errors.New("commit message required")
+	}
+
+	var result *types.CommitResult
+	err := a.journaled("commit", []string{"HEAD"}, func() error {
+		var err error
+		result, err = a.commit(message)
+		return err
+	})
+	return result, err
+}
+
+// commit creates a commit from the index, or from HEAD plus only the given
+// paths when any are passed.
+func (a *App) commit(message string, only ...string) (*types.CommitResult, error) {
+	args := []string{"commit", "-m", message}
+	if len(only) > 0 {
+		args = append([]string{"commit", "--only", "-m", message, "--"}, only...)
+	}
+
+	output, err := a.executor.Execute(args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to commit: %w", err)
 	}
 
 	sha := git.ExtractCommitSHA(output)
 
-	return &types.CommitResult{
+	result := &types.CommitResult{
 		Success:   true,
 		CommitSHA: sha,
 		Message:   message,
-	}, nil
+	}
+
+	// The commit is made either way, so a failure to size it is not an error.
+	if stats, err := a.numstat("show", "--numstat", "-z", "-M", "--format=", "HEAD"); err == nil {
+		result.Stats = &stats
+	}
+	return result, nil
 }
 
 // PushChanges pushes committed changes to the remote.
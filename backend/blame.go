@@ -0,0 +1,77 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// GetBlame attributes each line of path in lineRange to the commit that last
+// changed it, as of revision. An empty revision blames the working tree
+// copy, including uncommitted lines.
+func (a *App) GetBlame(path, revision string, lineRange types.LineRange, options types.BlameOptions) ([]types.BlameLine, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if path == "" {
+		return nil, errors.New("file path required")
+	}
+
+	args := []string{"blame", "--porcelain"}
+	if options.IgnoreWhitespace {
+		args = append(args, "-w")
+	}
+	if options.DetectMoves {
+		args = append(args, "-M")
+	}
+	if options.DetectCopies {
+		args = append(args, "-C")
+	}
+	if options.IgnoreRevsFile != "" {
+		args = append(args, "--ignore-revs-file", options.IgnoreRevsFile)
+	}
+
+	rangeArg, err := blameRange(lineRange)
+	if err != nil {
+		return nil, err
+	}
+	if rangeArg != "" {
+		args = append(args, "-L", rangeArg)
+	}
+
+	if revision != "" {
+		if err := checkRevision(revision); err != nil {
+			return nil, err
+		}
+		args = append(args, revision)
+	}
+	args = append(args, "--", path)
+
+	output, err := a.executor.Execute(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to blame %s: %w", path, err)
+	}
+
+	lines, err := git.ParseBlame(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse blame for %s: %w", path, err)
+	}
+	return lines, nil
+}
+
+// blameRange converts lineRange into a git blame -L argument, or returns ""
+// for the whole file.
+func blameRange(lineRange types.LineRange) (string, error) {
+	switch {
+	case lineRange.Start == 0 && lineRange.End == 0:
+		return "", nil
+	case lineRange.Start < 1 || (lineRange.End != 0 && lineRange.End < lineRange.Start):
+		return "", fmt.Errorf("invalid line range %d-%d", lineRange.Start, lineRange.End)
+	case lineRange.End == 0:
+		return strconv.Itoa(lineRange.Start) + ",", nil
+	}
+	return fmt.Sprintf("%d,%d", lineRange.Start, lineRange.End), nil
+}
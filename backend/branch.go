@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// GetBranchNamePolicy returns the naming policy applied to new branches.
+func (a *App) GetBranchNamePolicy() types.BranchNamePolicy {
+	return a.branchPolicy
+}
+
+// SetBranchNamePolicy replaces the naming policy applied to new branches.
+func (a *App) SetBranchNamePolicy(policy types.BranchNamePolicy) error {
+	if policy.TicketPattern != "" {
+		if _, err := regexp.Compile(policy.TicketPattern); err != nil {
+			return fmt.Errorf("invalid ticket pattern: %w", err)
+		}
+	}
+
+	a.branchPolicy = policy
+	return nil
+}
+
+// ValidateBranchName checks a branch name against git's ref format rules
+// and the configured naming policy.
+func (a *App) ValidateBranchName(name string) error {
+	if a.executor == nil {
+		return errors.New("no repository initialized")
+	}
+	if strings.TrimSpace(name) == "" {
+		return errors.New("branch name required")
+	}
+
+	if _, err := a.executor.Execute("check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+
+	return checkBranchPolicy(name, a.branchPolicy)
+}
+
+// CreateBranchFrom creates a new branch at startPoint, or HEAD when
+// startPoint is empty. When checkout is set the new branch is also
+// switched to, and track sets up upstream tracking against startPoint.
+func (a *App) CreateBranchFrom(name, startPoint string, checkout, track bool) error {
+	if err := a.ValidateBranchName(name); err != nil {
+		return err
+	}
+
+	var args []string
+	if checkout {
+		args = []string{"checkout", "-b", name}
+	} else {
+		args = []string{"branch", name}
+	}
+	if track {
+		args = append(args, "--track")
+	}
+	if startPoint != "" {
+		args = append(args, startPoint)
+	}
+
+	return a.journaled("create branch", []string{"refs/heads/" + name}, func() error {
+		_, err := a.executor.Execute(args...)
+		if err != nil {
+			return fmt.Errorf("failed to create branch %s: %w", name, err)
+		}
+
+		if checkout {
+			a.refreshHead()
+		}
+		return nil
+	})
+}
+
+// checkBranchPolicy reports the first naming rule that name violates.
+func checkBranchPolicy(name string, policy types.BranchNamePolicy) error {
+	if len(policy.Prefixes) > 0 {
+		allowed := false
+		for _, prefix := range policy.Prefixes {
+			if strings.HasPrefix(name, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("branch name %q must start with one of: %s", name, strings.Join(policy.Prefixes, ", "))
+		}
+	}
+
+	if policy.TicketPattern != "" {
+		re, err := regexp.Compile(policy.TicketPattern)
+		if err != nil {
+			return fmt.Errorf("invalid ticket pattern: %w", err)
+		}
+		if !re.MatchString(name) {
+			return fmt.Errorf("branch name %q must match ticket pattern %s", name, policy.TicketPattern)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// Compare shows what head would bring into base: the commits on either side
+// only, and the diff of every file from their merge base to head, like a
+// pull request of head into base.
+func (a *App) Compare(base, head string) (*types.Comparison, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	for _, revision := range []string{base, head} {
+		if revision == "" {
+			return nil, errors.New("both revisions are required")
+		}
+		if err := checkRevision(revision); err != nil {
+			return nil, err
+		}
+	}
+
+	comparison := &types.Comparison{Base: base, Head: head}
+	var err error
+	if comparison.BaseSHA, err = a.resolveCommit(base); err != nil {
+		return nil, err
+	}
+	if comparison.HeadSHA, err = a.resolveCommit(head); err != nil {
+		return nil, err
+	}
+
+	output, err := a.executor.Execute("merge-base", comparison.BaseSHA, comparison.HeadSHA)
+	var gitErr *git.GitError
+	if errors.As(err, &gitErr) && gitErr.ExitCode == 1 {
+		return nil, fmt.Errorf("%s and %s have no common history", base, head)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base of %s and %s: %w", base, head, err)
+	}
+	comparison.MergeBase = strings.TrimSpace(output)
+
+	if comparison.Ahead, err = a.commitsBetween(comparison.BaseSHA, comparison.HeadSHA); err != nil {
+		return nil, err
+	}
+	if comparison.Behind, err = a.commitsBetween(comparison.HeadSHA, comparison.BaseSHA); err != nil {
+		return nil, err
+	}
+
+	if comparison.Files, err = a.compareFiles(comparison.MergeBase, comparison.HeadSHA); err != nil {
+		return nil, err
+	}
+	comparison.Stats, err = a.numstat("diff", "--numstat", "-z", "-M", comparison.MergeBase, comparison.HeadSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats of %s..%s: %w", base, head, err)
+	}
+	comparison.Summary.FilesChanged = len(comparison.Files)
+	for _, file := range comparison.Files {
+		comparison.Summary.Insertions += file.Diff.Summary.Insertions
+		comparison.Summary.Deletions += file.Diff.Summary.Deletions
+		comparison.Summary.HunkCount += file.Diff.Summary.HunkCount
+	}
+
+	return comparison, nil
+}
+
+// commitsBetween lists the commits reachable from to but not from from,
+// newest first.
+func (a *App) commitsBetween(from, to string) ([]types.CommitSummary, error) {
+	output, err := a.executor.Execute("log", git.CommitSummaryFormat, from+".."+to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of %s..%s: %w", from, to, err)
+	}
+	return git.ParseCommitSummaries(output), nil
+}
+
+// compareFiles diffs every file changed from from to to. The file list and
+// the patch are matched up in order, so options that ignore whitespace are
+// left out: they drop files from the patch but not from the list.
+func (a *App) compareFiles(from, to string) ([]types.ComparedFile, error) {
+	output, err := a.executor.Execute("diff", "--name-status", "-z", "-M", from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+	files := git.ParseNameStatus(output)
+
+	options := a.diffOptions
+	options.IgnoreAllSpace, options.IgnoreSpaceChange, options.IgnoreBlankLines = false, false, false
+	args := append([]string{"diff"}, diffOptionArgs(options)...)
+	patch, err := a.executor.Execute(append(args, "-M", from, to)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s..%s: %w", from, to, err)
+	}
+
+	chunks := git.SplitDiff(patch)
+	if len(chunks) != len(files) {
+		return nil, fmt.Errorf("diff lists %d files but has %d patches", len(files), len(chunks))
+	}
+	for i := range files {
+		files[i].Diff, err = a.parseDiffOutput(files[i].Path, chunks[i], true)
+		if err != nil {
+			return nil, err
+		}
+		files[i].Diff.Mode = types.DiffRevisions
+		files[i].Diff.Diff = ""
+	}
+	return files, nil
+}
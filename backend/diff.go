@@ -0,0 +1,335 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// nullDevice is the path git diff --no-index treats as a missing file.
+const nullDevice = "/dev/null"
+
+// Diffs beyond these limits are returned as a summary only, so that huge
+// generated files do not flood the frontend. Their hunks can be loaded a
+// page at a time with GetDiffHunks.
+const (
+	maxDiffBytes = 1 << 20
+	maxDiffHunks = 300
+)
+
+// GetDiffOptions returns the options applied to every diff.
+func (a *App) GetDiffOptions() types.DiffOptions {
+	return a.diffOptions
+}
+
+// SetDiffOptions replaces the options applied to every diff.
+func (a *App) SetDiffOptions(options types.DiffOptions) error {
+	switch options.Algorithm {
+	case types.AlgorithmDefault, types.AlgorithmMyers, types.AlgorithmMinimal,
+		types.AlgorithmPatience, types.AlgorithmHistogram:
+	default:
+		return fmt.Errorf("unknown diff algorithm %q", options.Algorithm)
+	}
+	if options.ContextLines != nil && *options.ContextLines < 0 {
+		return fmt.Errorf("invalid context line count %d", *options.ContextLines)
+	}
+
+	a.diffOptions = options
+	return nil
+}
+
+// GetDiff returns the diff of a file between the two sides selected by
+// target, without falling back to any other comparison. Only the parsed
+// hunks are returned; the raw diff text is left empty.
+func (a *App) GetDiff(filePath string, target types.DiffTarget) (*types.DiffResult, error) {
+	output, err := a.rawDiff(filePath, target)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.parseDiffOutput(filePath, output, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Mode = target.Mode
+	result.Diff = ""
+	return result, nil
+}
+
+// GetDiffHunks returns up to limit hunks of the diff GetDiff produces for
+// the same file and target, starting at hunk offset. It is used to load
+// diffs that GetDiff reported as truncated, so only the lines of hunks in
+// the window are parsed.
+func (a *App) GetDiffHunks(filePath string, target types.DiffTarget, offset, limit int) (*types.DiffHunkPage, error) {
+	if offset < 0 || limit < 1 {
+		return nil, fmt.Errorf("invalid hunk window offset=%d limit=%d", offset, limit)
+	}
+
+	output, err := a.rawDiff(filePath, target)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &types.DiffHunkPage{FilePath: filePath, Offset: offset}
+	page.Hunks, page.TotalHunks = git.ParseDiffHunks(output, offset, limit)
+	return page, nil
+}
+
+// rawDiff runs git diff for a file between the sides of target, including
+// untracked files when the working tree is compared.
+func (a *App) rawDiff(filePath string, target types.DiffTarget) (string, error) {
+	if a.executor == nil {
+		return "", errors.New("no repository initialized")
+	}
+
+	args, err := diffArgs(target)
+	if err != nil {
+		return "", err
+	}
+
+	args = append(args, "--", filePath)
+	output, err := a.executor.Execute(a.diffCommand(args...)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s diff for %s: %w", target.Mode, filePath, err)
+	}
+
+	return a.resolveUntracked(filePath, output, comparesWorktree(target))
+}
+
+// GetFileDiffs returns both the staged and the unstaged diff of a file, so
+// a partially staged file can be reviewed in one call.
+func (a *App) GetFileDiffs(filePath string) (*types.FileDiffs, error) {
+	staged, err := a.GetDiff(filePath, types.DiffTarget{Mode: types.DiffIndexToHead})
+	if err != nil {
+		return nil, err
+	}
+
+	unstaged, err := a.GetDiff(filePath, types.DiffTarget{Mode: types.DiffWorktreeToIndex})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.FileDiffs{
+		FilePath: filePath,
+		Staged:   staged,
+		Unstaged: unstaged,
+	}, nil
+}
+
+// diffCommand builds a git diff command line with the configured diff
+// options placed before args.
+func (a *App) diffCommand(args ...string) []string {
+	command := append([]string{"diff"}, diffOptionArgs(a.diffOptions)...)
+	return append(command, args...)
+}
+
+// diffOptionArgs converts options into git diff flags.
+func diffOptionArgs(options types.DiffOptions) []string {
+	var args []string
+	if options.IgnoreAllSpace {
+		args = append(args, "-w")
+	}
+	if options.IgnoreSpaceChange {
+		args = append(args, "-b")
+	}
+	if options.IgnoreBlankLines {
+		args = append(args, "--ignore-blank-lines")
+	}
+	if options.ContextLines != nil {
+		args = append(args, fmt.Sprintf("-U%d", *options.ContextLines))
+	}
+	if options.Algorithm != types.AlgorithmDefault {
+		args = append(args, "--diff-algorithm="+string(options.Algorithm))
+	}
+	if options.WordDiff {
+		args = append(args, "--word-diff=porcelain")
+	}
+	if options.FunctionContext {
+		args = append(args, "--function-context")
+	}
+	return args
+}
+
+// diffArgs returns the git diff arguments that select the sides of target.
+func diffArgs(target types.DiffTarget) ([]string, error) {
+	switch target.Mode {
+	case types.DiffWorktreeToIndex:
+		return []string{}, nil
+	case types.DiffIndexToHead:
+		return []string{"--cached"}, nil
+	case types.DiffWorktreeToHead:
+		return []string{"HEAD"}, nil
+	case types.DiffRevisions:
+		if target.From == "" {
+			return nil, errors.New("revision diff requires a starting revision")
+		}
+		if err := checkRevision(target.From); err != nil {
+			return nil, err
+		}
+		if target.To == "" {
+			return []string{target.From}, nil
+		}
+		if err := checkRevision(target.To); err != nil {
+			return nil, err
+		}
+		return []string{target.From, target.To}, nil
+	}
+	return nil, fmt.Errorf("unknown diff mode %q", target.Mode)
+}
+
+// comparesWorktree reports whether the new side of target is the working
+// tree, where untracked files can appear.
+func comparesWorktree(target types.DiffTarget) bool {
+	switch target.Mode {
+	case types.DiffWorktreeToIndex, types.DiffWorktreeToHead:
+		return true
+	case types.DiffRevisions:
+		return target.To == ""
+	}
+	return false
+}
+
+// checkRevision rejects revisions that git would parse as an option.
+func checkRevision(rev string) error {
+	if strings.HasPrefix(rev, "-") {
+		return fmt.Errorf("invalid revision %q", rev)
+	}
+	return nil
+}
+
+// buildDiffResult parses diff output for filePath. An empty diff of an
+// untracked file is replaced by a diff against an empty file when
+// includeUntracked is set.
+func (a *App) buildDiffResult(filePath, output string, includeUntracked bool) (*types.DiffResult, error) {
+	output, err := a.resolveUntracked(filePath, output, includeUntracked)
+	if err != nil {
+		return nil, err
+	}
+	return a.parseDiffOutput(filePath, output, true)
+}
+
+// resolveUntracked returns output unchanged unless it is empty and filePath
+// is untracked, in which case the file is diffed against nothing.
+func (a *App) resolveUntracked(filePath, output string, includeUntracked bool) (string, error) {
+	if !includeUntracked || strings.TrimSpace(output) != "" {
+		return output, nil
+	}
+
+	// Untracked files are unknown to git diff, so compare against nothing
+	untracked, err := a.isUntracked(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to check whether %s is tracked: %w", filePath, err)
+	}
+	if !untracked {
+		return output, nil
+	}
+
+	output, err = a.untrackedDiff(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff for untracked file %s: %w", filePath, err)
+	}
+	return output, nil
+}
+
+// parseDiffOutput parses the diff of filePath and adds size metadata to
+// binary changes. With limit set, diffs above maxDiffBytes or maxDiffHunks
+// are reduced to their header and summary.
+func (a *App) parseDiffOutput(filePath, output string, limit bool) (*types.DiffResult, error) {
+	var summary types.DiffSummary
+	truncated := false
+	if limit {
+		summary = git.SummarizeDiff(output)
+		truncated = len(output) > maxDiffBytes || summary.HunkCount > maxDiffHunks
+	}
+
+	parsed := output
+	if truncated {
+		parsed = git.DiffHeader(output)
+	}
+
+	result, err := git.ParseDiff(filePath, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff for %s: %w", filePath, err)
+	}
+
+	if truncated {
+		result.Diff = ""
+		result.Summary = summary
+		result.Truncated = true
+	}
+
+	if result.IsBinary {
+		result.Binary = a.binaryInfo(result)
+	}
+
+	return result, nil
+}
+
+// isUntracked reports whether filePath is an untracked, non-ignored file.
+func (a *App) isUntracked(filePath string) (bool, error) {
+	output, err := a.executor.Execute("ls-files", "--others", "--exclude-standard", "--", filePath)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) != "", nil
+}
+
+// untrackedDiff diffs an untracked file against an empty file. git exits
+// with status 1 when the two differ, which is the expected outcome here.
+func (a *App) untrackedDiff(filePath string) (string, error) {
+	output, err := a.executor.Execute(a.diffCommand("--no-index", "--", nullDevice, filePath)...)
+	var gitErr *git.GitError
+	if errors.As(err, &gitErr) && gitErr.ExitCode == 1 {
+		return gitErr.Output, nil
+	}
+	return output, err
+}
+
+// binaryInfo looks up the sizes of both sides of a binary change. Blobs that
+// are not in the object database yet are read from the working tree.
+func (a *App) binaryInfo(result *types.DiffResult) *types.BinaryInfo {
+	info := &types.BinaryInfo{
+		MimeType: mime.TypeByExtension(filepath.Ext(result.FilePath)),
+	}
+	if info.MimeType == "" {
+		info.MimeType = "application/octet-stream"
+	}
+
+	if !result.IsNew {
+		info.OldSize = a.blobSize(result.OldBlob, "")
+	}
+	if !result.IsDeleted {
+		info.NewSize = a.blobSize(result.NewBlob, result.FilePath)
+	}
+
+	return info
+}
+
+// blobSize returns the size of blob, falling back to the working tree copy
+// of filePath when the blob cannot be read.
+func (a *App) blobSize(blob, filePath string) int64 {
+	if blob != "" && strings.Trim(blob, "0") != "" {
+		output, err := a.executor.Execute("cat-file", "-s", blob)
+		if err == nil {
+			size, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+			if err == nil {
+				return size
+			}
+		}
+	}
+
+	if filePath != "" && a.repo != nil {
+		if stat, err := os.Stat(filepath.Join(a.repo.Path, filePath)); err == nil {
+			return stat.Size()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,219 @@
+package backend
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// DiscardFile throws away the working tree changes of a file by restoring
+// it from the index. An untracked file or directory is deleted instead,
+// which first returns NeedsConfirmation and a token identifying its current
+// content; calling again with that token performs the deletion. The content
+// is snapshotted before anything is discarded.
+func (a *App) DiscardFile(path, confirmToken string) (*types.DiscardResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	output, err := a.executor.Execute("ls-files", "--others", "--exclude-standard", "-z", "--", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check whether %s is tracked: %w", path, err)
+	}
+	files := git.ParsePathList(output)
+	untracked := len(files) > 0
+
+	result := &types.DiscardResult{Paths: []string{path}}
+	if untracked {
+		result.Paths = files
+		token, err := a.untrackedToken(path, files)
+		if err != nil {
+			return nil, err
+		}
+		if confirmToken != token {
+			result.NeedsConfirmation = true
+			result.ConfirmToken = token
+			return result, nil
+		}
+	}
+
+	err = a.record("discard file", nil, func() (*worktreeChange, error) {
+		var err error
+		if result.SnapshotID, err = a.snapshotFiles("discard "+path, result.Paths); err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", path, err)
+		}
+
+		args := []string{"checkout", "--", path}
+		if untracked {
+			args = []string{"clean", "-f", "--", path}
+		}
+		if _, err := a.executor.Execute(args...); err != nil {
+			return nil, fmt.Errorf("failed to discard %s: %w", path, err)
+		}
+
+		return &worktreeChange{paths: result.Paths, snapshotID: result.SnapshotID}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// untrackedToken returns the confirmation token for deleting the untracked
+// files under path. A single file is identified by its blob hash; the files
+// of a directory by a digest of their paths and blob hashes.
+func (a *App) untrackedToken(path string, files []string) (string, error) {
+	output, err := a.executor.Execute(append([]string{"hash-object", "--"}, files...)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	if len(files) == 1 && files[0] == path {
+		return strings.TrimSpace(output), nil
+	}
+
+	digest := sha1.New()
+	for _, file := range files {
+		digest.Write([]byte(file + "\x00"))
+	}
+	digest.Write([]byte(output))
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// DiscardHunk reverts a single hunk of the unstaged diff of a file. The
+// hunk index refers to the diff GetDiff returns for the worktree-index mode.
+func (a *App) DiscardHunk(path string, hunkIndex int) (*types.DiscardResult, error) {
+	if ignoresWhitespace(a.diffOptions) {
+		return nil, errors.New("hunks cannot be discarded while whitespace changes are ignored; turn the whitespace options off first")
+	}
+
+	return a.discardPatch(path, hunkIndex, func(hunk types.DiffHunk) (types.DiffHunk, error) {
+		return hunk, nil
+	})
+}
+
+// DiscardLines reverts the selected added and deleted lines of one hunk of
+// the unstaged diff of a file. Line indexes refer to DiffHunk.Lines.
+func (a *App) DiscardLines(path string, hunkIndex int, lineIndexes []int) (*types.DiscardResult, error) {
+	if ignoresWhitespace(a.diffOptions) {
+		return nil, errors.New("lines cannot be discarded while whitespace changes are ignored; turn the whitespace options off first")
+	}
+	if a.diffOptions.WordDiff {
+		return nil, errors.New("lines cannot be discarded from a word diff; turn word diff off first")
+	}
+
+	return a.discardPatch(path, hunkIndex, func(hunk types.DiffHunk) (types.DiffHunk, error) {
+		return git.SelectHunkLines(hunk, lineIndexes)
+	})
+}
+
+// CleanUntracked deletes untracked, non-ignored files under paths, or the
+// whole working tree when paths is empty. With dryRun set the files are
+// only listed.
+func (a *App) CleanUntracked(paths []string, dryRun bool) (*types.CleanResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	output, err := a.executor.Execute(append([]string{"ls-files", "--others", "--exclude-standard", "-z", "--"}, paths...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	result := &types.CleanResult{Paths: git.ParsePathList(output), DryRun: dryRun}
+	if dryRun || len(result.Paths) == 0 {
+		return result, nil
+	}
+
+	err = a.record("clean", nil, func() (*worktreeChange, error) {
+		var err error
+		if result.SnapshotID, err = a.snapshotFiles("clean", result.Paths); err != nil {
+			return nil, fmt.Errorf("failed to snapshot untracked files: %w", err)
+		}
+		if _, err := a.executor.Execute(append([]string{"clean", "-f", "--"}, result.Paths...)...); err != nil {
+			return nil, fmt.Errorf("failed to clean untracked files: %w", err)
+		}
+
+		return &worktreeChange{paths: result.Paths, snapshotID: result.SnapshotID}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// discardPatch snapshots a file and reverse-applies the hunk returned by
+// selectHunk to its working tree copy.
+func (a *App) discardPatch(path string, hunkIndex int, selectHunk func(types.DiffHunk) (types.DiffHunk, error)) (*types.DiscardResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	output, err := a.executor.Execute(append(patchDiffCommand(a.diffOptions), "--", path)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff for %s: %w", path, err)
+	}
+
+	diff, err := git.ParseDiff(path, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff for %s: %w", path, err)
+	}
+	if hunkIndex < 0 || hunkIndex >= len(diff.Hunks) {
+		return nil, fmt.Errorf("hunk index %d out of range for %s", hunkIndex, path)
+	}
+
+	hunk, err := selectHunk(diff.Hunks[hunkIndex])
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.DiscardResult{Paths: []string{path}}
+	err = a.record("discard hunk", nil, func() (*worktreeChange, error) {
+		var err error
+		if result.SnapshotID, err = a.snapshotFiles("discard hunk of "+path, []string{path}); err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", path, err)
+		}
+
+		patch := git.FormatPatch(path, []types.DiffHunk{hunk})
+		if _, err := a.executor.ExecuteWithInput(patch, applyArgs(a.diffOptions)...); err != nil {
+			return nil, fmt.Errorf("failed to discard changes in %s: %w", path, err)
+		}
+
+		return &worktreeChange{paths: result.Paths, snapshotID: result.SnapshotID}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// patchDiffCommand builds a git diff command whose hunks line up with the
+// ones shown under options but can be applied as a patch. Word diffs keep
+// the hunks of a line diff, so only their line format is turned off.
+func patchDiffCommand(options types.DiffOptions) []string {
+	options.WordDiff = false
+	return append([]string{"diff"}, diffOptionArgs(options)...)
+}
+
+// applyArgs returns the git apply arguments that reverse a patch built
+// under options. Hunks without context lines need --unidiff-zero.
+func applyArgs(options types.DiffOptions) []string {
+	args := []string{"apply", "--reverse", "--recount"}
+	if options.ContextLines != nil && *options.ContextLines == 0 {
+		args = append(args, "--unidiff-zero")
+	}
+	return args
+}
+
+// ignoresWhitespace reports whether options hide whitespace changes, which
+// leaves the hunks shown different from the ones a patch has to contain.
+func ignoresWhitespace(options types.DiffOptions) bool {
+	return options.IgnoreAllSpace || options.IgnoreSpaceChange || options.IgnoreBlankLines
+}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// ParseBlame parses `git blame --porcelain` output. Commit details are only
+// printed the first time a commit appears, so they are carried over to its
+// later lines.
+func ParseBlame(output string) ([]types.BlameLine, error) {
+	lines := []types.BlameLine{}
+	commits := make(map[string]*types.BlameLine)
+	var current *types.BlameLine
+
+	for _, raw := range strings.Split(output, "\n") {
+		if content, ok := strings.CutPrefix(raw, "\t"); ok {
+			if current == nil {
+				return nil, fmt.Errorf("blame content without header: %q", raw)
+			}
+			line := *current
+			line.Content = content
+			lines = append(lines, line)
+			current = nil
+			continue
+		}
+		if raw == "" {
+			continue
+		}
+
+		if current == nil {
+			fields := strings.Fields(raw)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("invalid blame header: %q", raw)
+			}
+			original, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid blame header: %q", raw)
+			}
+			final, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid blame header: %q", raw)
+			}
+
+			commit, ok := commits[fields[0]]
+			if !ok {
+				commit = &types.BlameLine{SHA: fields[0]}
+				commits[fields[0]] = commit
+			}
+			current = commit
+			current.OriginalLine = original
+			current.LineNumber = final
+			continue
+		}
+
+		key, value, _ := strings.Cut(raw, " ")
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.AuthorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			current.AuthorTime, _ = strconv.ParseInt(value, 10, 64)
+		case "summary":
+			current.Summary = value
+		case "filename":
+			current.OriginalPath = value
+		}
+	}
+
+	return lines, nil
+}
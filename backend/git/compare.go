@@ -0,0 +1,50 @@
+package git
+
+import (
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// ParseNameStatus parses `git diff --name-status -z` output into the changed
+// files, without their diffs.
+func ParseNameStatus(output string) []types.ComparedFile {
+	files := []types.ComparedFile{}
+	fields := strings.Split(strings.TrimRight(output, "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		file := types.ComparedFile{Status: changeStatus(fields[i]), Path: fields[i+1]}
+		if file.Status == types.StatusRenamed && i+2 < len(fields) {
+			file.OldPath = fields[i+1]
+			file.Path = fields[i+2]
+			i++
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// SplitDiff splits multi-file diff output into the diff of each file, in
+// the order git printed them. The deletion and creation patches of a type
+// change share a header line and stay together.
+func SplitDiff(output string) []string {
+	var chunks []string
+	for output != "" {
+		chunk := output
+		if next := strings.Index(output, "\ndiff --git "); next != -1 {
+			chunk = output[:next+1]
+		}
+		output = output[len(chunk):]
+
+		if last := len(chunks) - 1; last >= 0 && firstLine(chunks[last]) == firstLine(chunk) {
+			chunks[last] += chunk
+		} else {
+			chunks = append(chunks, chunk)
+		}
+	}
+	return chunks
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -1,7 +1,10 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
 )
@@ -9,6 +12,25 @@ import (
 // GitExecutor defines the interface for executing git commands.
 type GitExecutor interface {
 	Execute(args ...string) (string, error)
+	// ExecuteWithInput runs a git command with input written to its stdin,
+	// for commands such as `git apply` that read patches from stdin.
+	ExecuteWithInput(input string, args ...string) (string, error)
+	// ExecuteWithEnv runs a git command with extra "KEY=value" environment
+	// variables, for settings such as GIT_EDITOR that override config.
+	ExecuteWithEnv(env []string, args ...string) (string, error)
+}
+
+// GitError is returned when a git command exits with a non-zero status.
+// Some commands, such as `git diff --no-index`, use exit code 1 to report
+// differences rather than failure, so the output is kept for callers.
+type GitError struct {
+	Args     []string
+	Output   string
+	ExitCode int
+}
+
+func (e *GitError) Error() string {
+	return fmt.Sprintf("git %s failed: %s", strings.Join(e.Args, " "), strings.TrimSpace(e.Output))
 }
 
 // RealGitExecutor executes git commands as subprocesses.
@@ -21,11 +43,31 @@ func NewGitExecutor(repoPath string) *RealGitExecutor {
 }
 
 func (e *RealGitExecutor) Execute(args ...string) (string, error) {
+	return e.run(nil, nil, args)
+}
+
+func (e *RealGitExecutor) ExecuteWithInput(input string, args ...string) (string, error) {
+	return e.run(strings.NewReader(input), nil, args)
+}
+
+func (e *RealGitExecutor) ExecuteWithEnv(env []string, args ...string) (string, error) {
+	return e.run(nil, env, args)
+}
+
+func (e *RealGitExecutor) run(stdin io.Reader, env []string, args []string) (string, error) {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = e.repoPath
+	cmd.Stdin = stdin
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", &GitError{Args: args, Output: string(output), ExitCode: exitErr.ExitCode()}
+		}
 		return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(output)))
 	}
 
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// FileHistoryFormat is the `git log --format` that ParseFileHistory expects,
+// combined with --raw, -p and -z.
+const FileHistoryFormat = "--format=%x1e%H%x00%an%x00%ae%x00%at%x00%s%x00"
+
+// FileHistoryRecord is a commit of `git log --follow` output along with the
+// unparsed diff of the followed file.
+type FileHistoryRecord struct {
+	Entry types.FileHistoryEntry
+	Diff  string
+}
+
+// ParseFileHistory parses `git log --follow --raw -p -z` output in
+// FileHistoryFormat. Commits without a change to the file, such as merges,
+// keep the path of the newer commit before them, starting with path.
+func ParseFileHistory(path, output string) ([]FileHistoryRecord, error) {
+	records := []FileHistoryRecord{}
+	for _, chunk := range strings.Split(output, "\x1e") {
+		if strings.Trim(chunk, "\x00\n") == "" {
+			continue
+		}
+
+		fields := strings.SplitN(chunk, "\x00", 6)
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("invalid history record: %q", chunk)
+		}
+		authorTime, _ := strconv.ParseInt(fields[3], 10, 64)
+		record := FileHistoryRecord{Entry: types.FileHistoryEntry{
+			SHA:         fields[0],
+			Author:      fields[1],
+			AuthorEmail: fields[2],
+			AuthorTime:  authorTime,
+			Subject:     fields[4],
+			Path:        path,
+			Status:      types.StatusModified,
+		}}
+
+		rest := strings.TrimLeft(fields[5], "\x00\n")
+		if strings.HasPrefix(rest, ":") {
+			rest = parseRawChange(&record.Entry, rest)
+		}
+		record.Diff = strings.TrimRight(rest, "\x00")
+		path = record.Entry.Path
+
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// parseRawChange fills in the status and paths of entry from a -z raw diff
+// line at the start of rest and returns what follows it.
+func parseRawChange(entry *types.FileHistoryEntry, rest string) string {
+	meta, rest, _ := strings.Cut(rest, "\x00")
+	fields := strings.Fields(meta)
+	status := ""
+	if len(fields) > 0 {
+		status = fields[len(fields)-1]
+	}
+
+	var name string
+	name, rest, _ = strings.Cut(rest, "\x00")
+	entry.Status = changeStatus(status)
+	if entry.Status == types.StatusRenamed {
+		entry.OldPath = name
+		name, rest, _ = strings.Cut(rest, "\x00")
+	}
+	entry.Path = name
+
+	return strings.TrimLeft(rest, "\x00")
+}
+
+// changeStatus converts a diff status letter, such as "M" or "R100", into a
+// StatusType.
+func changeStatus(status string) types.StatusType {
+	switch {
+	case strings.HasPrefix(status, "R"):
+		return types.StatusRenamed
+	case status == "A":
+		return types.StatusAdded
+	case status == "D":
+		return types.StatusDeleted
+	}
+	return types.StatusModified
+}
@@ -0,0 +1,169 @@
+package git
+
+import (
+	"strings"
+	"unicode"
+
+	"git-gui/backend/types"
+)
+
+// maxWordDiffCells bounds the LCS table used to compare the differing middle
+// of two lines. Larger regions are reported as a single changed span.
+const maxWordDiffCells = 1 << 16
+
+// token is a word, whitespace run or punctuation character of a line, with
+// its position in runes.
+type token struct {
+	text  string
+	start int
+	end   int
+}
+
+// markIntraLineChanges pairs each run of deleted lines with the run of added
+// lines that follows it and records the word-level spans that differ.
+func markIntraLineChanges(lines []types.DiffLine) {
+	for i := 0; i < len(lines); {
+		var deletes, adds []int
+		j := i
+		for ; j < len(lines) && (lines[j].Kind == types.LineDelete || lines[j].Kind == types.LineNoNewline); j++ {
+			if lines[j].Kind == types.LineDelete {
+				deletes = append(deletes, j)
+			}
+		}
+		for ; j < len(lines) && (lines[j].Kind == types.LineAdd || lines[j].Kind == types.LineNoNewline); j++ {
+			if lines[j].Kind == types.LineAdd {
+				adds = append(adds, j)
+			}
+		}
+		if j == i {
+			i++
+			continue
+		}
+
+		for k := 0; k < len(deletes) && k < len(adds); k++ {
+			oldLine, newLine := &lines[deletes[k]], &lines[adds[k]]
+			oldLine.Changes, newLine.Changes = WordDiff(oldLine.Content, newLine.Content)
+		}
+		i = j
+	}
+}
+
+// WordDiff compares two lines word by word and returns the changed spans of
+// each. Lines with nothing but whitespace in common return no spans, since
+// highlighting the whole line adds nothing over the line's kind.
+func WordDiff(oldText, newText string) ([]types.LineSpan, []types.LineSpan) {
+	a, b := tokenize(oldText), tokenize(newText)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix].text == b[prefix].text {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix].text == b[len(b)-1-suffix].text {
+		suffix++
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	keepA, keepB := make([]bool, len(midA)), make([]bool, len(midB))
+	if len(midA)*len(midB) <= maxWordDiffCells {
+		matchTokens(midA, midB, keepA, keepB)
+	}
+
+	common := hasWord(a[:prefix]) || hasWord(a[len(a)-suffix:])
+	for i, keep := range keepA {
+		if keep && strings.TrimSpace(midA[i].text) != "" {
+			common = true
+		}
+	}
+	if !common {
+		return nil, nil
+	}
+
+	return changedSpans(midA, keepA), changedSpans(midB, keepB)
+}
+
+// matchTokens marks the tokens of a and b that belong to their longest
+// common subsequence.
+func matchTokens(a, b []token, keepA, keepB []bool) {
+	n, m := len(a), len(b)
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i].text == b[j].text {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i].text == b[j].text:
+			keepA[i], keepB[j] = true, true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+}
+
+// changedSpans merges consecutive unmatched tokens into spans.
+func changedSpans(tokens []token, keep []bool) []types.LineSpan {
+	var spans []types.LineSpan
+	for i, tok := range tokens {
+		if keep[i] {
+			continue
+		}
+		if n := len(spans); n > 0 && spans[n-1].End == tok.start {
+			spans[n-1].End = tok.end
+		} else {
+			spans = append(spans, types.LineSpan{Start: tok.start, End: tok.end})
+		}
+	}
+	return spans
+}
+
+// tokenize splits text into words, whitespace runs and single punctuation
+// characters.
+func tokenize(text string) []token {
+	var tokens []token
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, token{text: string(runes[i:j]), start: i, end: j})
+		i = j
+	}
+
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func hasWord(tokens []token) bool {
+	for _, tok := range tokens {
+		if strings.TrimSpace(tok.text) != "" {
+			return true
+		}
+	}
+	return false
+}
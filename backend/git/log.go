@@ -0,0 +1,49 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// CommitSummaryFormat is the `git log --format` that ParseCommitSummaries
+// expects.
+const CommitSummaryFormat = "--format=%H%x00%s"
+
+// ParseCommitSummaries parses `git log` output in CommitSummaryFormat.
+func ParseCommitSummaries(output string) []types.CommitSummary {
+	commits := []types.CommitSummary{}
+	for _, line := range strings.Split(output, "\n") {
+		sha, subject, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		commits = append(commits, types.CommitSummary{SHA: sha, Subject: subject})
+	}
+	return commits
+}
+
+// GraphCommitFormat is the `git log --format` that ParseGraphCommits
+// expects.
+const GraphCommitFormat = "--format=%H%x00%P%x00%an%x00%at%x00%s"
+
+// ParseGraphCommits parses `git log` output in GraphCommitFormat.
+func ParseGraphCommits(output string) []types.GraphCommit {
+	commits := []types.GraphCommit{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x00", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		authorTime, _ := strconv.ParseInt(fields[3], 10, 64)
+		commits = append(commits, types.GraphCommit{
+			SHA:        fields[0],
+			Parents:    strings.Fields(fields[1]),
+			Author:     fields[2],
+			AuthorTime: authorTime,
+			Subject:    fields[4],
+		})
+	}
+	return commits
+}
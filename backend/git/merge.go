@@ -0,0 +1,17 @@
+package git
+
+import "strings"
+
+// ParseMergeTree parses the output of
+// `git merge-tree --write-tree -z --name-only --no-messages`, which is the
+// resulting tree followed by the paths left with conflicts.
+func ParseMergeTree(output string) (string, []string) {
+	fields := strings.Split(strings.TrimRight(output, "\x00"), "\x00")
+	conflicts := []string{}
+	for _, path := range fields[1:] {
+		if path != "" {
+			conflicts = append(conflicts, path)
+		}
+	}
+	return strings.TrimSpace(fields[0]), conflicts
+}
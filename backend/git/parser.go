@@ -88,6 +88,9 @@ func ParseBranches(output string) ([]types.Branch, error) {
 	return branches, nil
 }
 
+// symlinkMode is the git file mode used for symbolic links.
+const symlinkMode = "120000"
+
 // ParseDiff parses unified diff output into a DiffResult.
 func ParseDiff(filePath, output string) (*types.DiffResult, error) {
 	result := &types.DiffResult{
@@ -100,30 +103,247 @@ func ParseDiff(filePath, output string) (*types.DiffResult, error) {
 		return result, nil
 	}
 
+	result.Summary = SummarizeDiff(output)
+	for _, hunk := range scanDiff(result, output) {
+		result.Hunks = append(result.Hunks, hunk.parse())
+	}
+
+	if result.IsSymlink {
+		result.OldSymlinkTarget, result.NewSymlinkTarget = symlinkTargets(result)
+	}
+
+	return result, nil
+}
+
+// ParseDiffHunks parses up to limit hunks of diff output starting at hunk
+// offset and returns them with the total number of hunks. Lines of hunks
+// outside the window are split off but never parsed.
+func ParseDiffHunks(output string, offset, limit int) ([]types.DiffHunk, int) {
+	scanned := scanDiff(&types.DiffResult{}, output)
+	start := min(offset, len(scanned))
+	end := min(offset+limit, len(scanned))
+
+	hunks := make([]types.DiffHunk, 0, end-start)
+	for _, hunk := range scanned[start:end] {
+		hunks = append(hunks, hunk.parse())
+	}
+	return hunks, len(scanned)
+}
+
+// rawHunk is a hunk whose header has been parsed but whose lines have not.
+type rawHunk struct {
+	types.DiffHunk
+	lines []string
+}
+
+// parse builds the typed lines of the hunk.
+func (h rawHunk) parse() types.DiffHunk {
+	hunk := h.DiffHunk
+	hunk.Lines = ParseHunkLines(hunk.OldStart, hunk.NewStart, h.lines)
+	return hunk
+}
+
+// scanDiff records the extended header of diff output in result and splits
+// the output into hunks without parsing their lines.
+func scanDiff(result *types.DiffResult, output string) []rawHunk {
 	lines := strings.Split(output, "\n")
-	var currentHunk *types.DiffHunk
+	counted := !isWordDiff(lines)
+	var hunks []rawHunk
+	var current *rawHunk
+	var oldLeft, newLeft int
+
+	// A type change is printed as a deletion followed by a creation of the
+	// same path; header lines of each later patch are collected separately.
+	header := result
+	var laterPatches []*types.DiffResult
+	seenPatch := false
+
+	flush := func() {
+		if current != nil {
+			hunks = append(hunks, *current)
+		}
+		current = nil
+	}
 
 	for _, line := range lines {
-		if strings.HasPrefix(line, "@@") {
-			if currentHunk != nil {
-				result.Hunks = append(result.Hunks, *currentHunk)
-			}
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			flush()
 			hunk, err := ParseHunkHeader(line)
 			if err != nil {
-				currentHunk = &types.DiffHunk{Header: line}
-			} else {
-				currentHunk = hunk
+				hunk = &types.DiffHunk{Header: line}
+			}
+			current = &rawHunk{DiffHunk: *hunk}
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+		case current != nil && hunkBodyLine(line, counted, &oldLeft, &newLeft):
+			current.lines = append(current.lines, line)
+		default:
+			flush()
+			if strings.HasPrefix(line, "diff --git ") {
+				if seenPatch {
+					header = &types.DiffResult{}
+					laterPatches = append(laterPatches, header)
+				}
+				seenPatch = true
 			}
-		} else if currentHunk != nil {
-			currentHunk.Lines = append(currentHunk.Lines, line)
+			parseDiffHeaderLine(header, line)
 		}
 	}
+	flush()
 
-	if currentHunk != nil {
-		result.Hunks = append(result.Hunks, *currentHunk)
+	for _, patch := range laterPatches {
+		mergeTypeChange(result, patch)
 	}
+	return hunks
+}
 
-	return result, nil
+// hunkBodyLine reports whether line belongs to the hunk being read and, for
+// counted hunks, takes it off the old and new line counts left. A hunk ends
+// once both counts are used up, apart from a trailing "\ No newline" marker.
+// Word diff lines do not map onto the counts, so those hunks run until the
+// next patch.
+func hunkBodyLine(line string, counted bool, oldLeft, newLeft *int) bool {
+	if !counted {
+		return !strings.HasPrefix(line, "diff --git ")
+	}
+	if strings.HasPrefix(line, "\\") {
+		return true
+	}
+	if *oldLeft <= 0 && *newLeft <= 0 {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(line, "+"):
+		*newLeft--
+	case strings.HasPrefix(line, "-"):
+		*oldLeft--
+	default:
+		*oldLeft--
+		*newLeft--
+	}
+	return true
+}
+
+// mergeTypeChange folds the creation patch of a type change into the result
+// of the deletion patch before it, so the result describes one change from
+// the old mode to the new one.
+func mergeTypeChange(result, patch *types.DiffResult) {
+	if patch.NewMode != "" {
+		result.NewMode = patch.NewMode
+	}
+	if patch.NewBlob != "" {
+		result.NewBlob = patch.NewBlob
+	}
+	if result.IsDeleted && patch.IsNew {
+		result.IsDeleted, result.IsNew = false, false
+	}
+	result.IsBinary = result.IsBinary || patch.IsBinary
+	result.IsSymlink = result.IsSymlink || patch.IsSymlink
+}
+
+// SummarizeDiff counts files, hunks and added and removed lines in diff
+// output in a single pass, without building hunks.
+func SummarizeDiff(output string) types.DiffSummary {
+	var summary types.DiffSummary
+	inHunk := false
+	lastPatch := ""
+
+	for len(output) > 0 {
+		line := output
+		if idx := strings.IndexByte(output, '\n'); idx != -1 {
+			line, output = output[:idx], output[idx+1:]
+		} else {
+			output = ""
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			// Both patches of a type change share the same header line.
+			if line != lastPatch {
+				summary.FilesChanged++
+			}
+			lastPatch = line
+			inHunk = false
+		case strings.HasPrefix(line, "@@"):
+			summary.HunkCount++
+			inHunk = true
+		case inHunk && strings.HasPrefix(line, "+"):
+			summary.Insertions++
+		case inHunk && strings.HasPrefix(line, "-"):
+			summary.Deletions++
+		}
+	}
+
+	if summary.FilesChanged == 0 && summary.HunkCount > 0 {
+		summary.FilesChanged = 1
+	}
+	return summary
+}
+
+// DiffHeader returns the part of diff output before its first hunk.
+func DiffHeader(output string) string {
+	if strings.HasPrefix(output, "@@") {
+		return ""
+	}
+	if idx := strings.Index(output, "\n@@"); idx != -1 {
+		return output[:idx+1]
+	}
+	return output
+}
+
+// parseDiffHeaderLine records mode, blob and binary information from an
+// extended header line that precedes the first hunk.
+func parseDiffHeaderLine(result *types.DiffResult, line string) {
+	switch {
+	case strings.HasPrefix(line, "new file mode "):
+		result.IsNew = true
+		result.NewMode = strings.TrimPrefix(line, "new file mode ")
+	case strings.HasPrefix(line, "deleted file mode "):
+		result.IsDeleted = true
+		result.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+	case strings.HasPrefix(line, "old mode "):
+		result.OldMode = strings.TrimPrefix(line, "old mode ")
+	case strings.HasPrefix(line, "new mode "):
+		result.NewMode = strings.TrimPrefix(line, "new mode ")
+	case strings.HasPrefix(line, "index "):
+		fields := strings.Fields(strings.TrimPrefix(line, "index "))
+		if len(fields) == 0 {
+			return
+		}
+		if blobs := strings.SplitN(fields[0], "..", 2); len(blobs) == 2 {
+			result.OldBlob, result.NewBlob = blobs[0], blobs[1]
+		}
+		// "index abc..def 100644" means the mode did not change.
+		if len(fields) == 2 && result.OldMode == "" && result.NewMode == "" {
+			result.OldMode, result.NewMode = fields[1], fields[1]
+		}
+	case strings.HasPrefix(line, "Binary files ") && strings.HasSuffix(line, " differ"),
+		line == "GIT binary patch":
+		result.IsBinary = true
+	}
+
+	if result.OldMode == symlinkMode || result.NewMode == symlinkMode {
+		result.IsSymlink = true
+	}
+}
+
+// symlinkTargets extracts the old and new link targets from a symlink diff,
+// where the blob content is the target path. Only the sides whose mode is a
+// symlink have a target; the other side of a type change is file content.
+func symlinkTargets(result *types.DiffResult) (string, string) {
+	var oldTarget, newTarget string
+	for _, hunk := range result.Hunks {
+		for _, line := range hunk.Lines {
+			switch {
+			case line.Kind == types.LineDelete && result.OldMode == symlinkMode:
+				oldTarget = line.Content
+			case line.Kind == types.LineAdd && result.NewMode == symlinkMode:
+				newTarget = line.Content
+			}
+		}
+	}
+	return oldTarget, newTarget
 }
 
 // ParseHunkHeader parses a @@ hunk header line like "@@ -1,3 +1,4 @@".
@@ -136,17 +356,22 @@ func ParseHunkHeader(header string) (*types.DiffHunk, error) {
 		return hunk, fmt.Errorf("invalid hunk header: %s", header)
 	}
 
+	if len(parts) == 3 {
+		hunk.Section = strings.TrimSpace(parts[2])
+	}
+
 	rangeInfo := strings.TrimSpace(parts[1])
 	ranges := strings.Split(rangeInfo, " ")
 	if len(ranges) < 2 {
 		return hunk, fmt.Errorf("invalid range info: %s", rangeInfo)
 	}
 
-	// Parse old range (-X,Y)
+	// Parse old range (-X,Y); a missing count means a single line
 	oldRange := strings.TrimPrefix(ranges[0], "-")
 	oldParts := strings.Split(oldRange, ",")
 	if len(oldParts) >= 1 {
 		hunk.OldStart, _ = strconv.Atoi(oldParts[0])
+		hunk.OldLines = 1
 	}
 	if len(oldParts) >= 2 {
 		hunk.OldLines, _ = strconv.Atoi(oldParts[1])
@@ -157,6 +382,7 @@ func ParseHunkHeader(header string) (*types.DiffHunk, error) {
 	newParts := strings.Split(newRange, ",")
 	if len(newParts) >= 1 {
 		hunk.NewStart, _ = strconv.Atoi(newParts[0])
+		hunk.NewLines = 1
 	}
 	if len(newParts) >= 2 {
 		hunk.NewLines, _ = strconv.Atoi(newParts[1])
@@ -165,6 +391,132 @@ func ParseHunkHeader(header string) (*types.DiffHunk, error) {
 	return hunk, nil
 }
 
+// ParseHunkLines converts the raw lines of a hunk into typed lines numbered
+// from oldStart and newStart, then marks intra-line changes between paired
+// deleted and added lines. Output of --word-diff=porcelain is recognised by
+// its "~" line terminators.
+func ParseHunkLines(oldStart, newStart int, raw []string) []types.DiffLine {
+	if isWordDiff(raw) {
+		return parseWordDiffLines(oldStart, newStart, raw)
+	}
+
+	lines := make([]types.DiffLine, 0, len(raw))
+	oldNo, newNo := oldStart, newStart
+
+	for _, line := range raw {
+		if line == "" {
+			continue
+		}
+
+		content := line[1:]
+		switch line[0] {
+		case '+':
+			lines = append(lines, types.DiffLine{Kind: types.LineAdd, NewLineNo: newNo, Content: content})
+			newNo++
+		case '-':
+			lines = append(lines, types.DiffLine{Kind: types.LineDelete, OldLineNo: oldNo, Content: content})
+			oldNo++
+		case '\\':
+			lines = append(lines, types.DiffLine{Kind: types.LineNoNewline, Content: strings.TrimSpace(content)})
+		default:
+			lines = append(lines, types.DiffLine{Kind: types.LineContext, OldLineNo: oldNo, NewLineNo: newNo, Content: content})
+			oldNo++
+			newNo++
+		}
+	}
+
+	markIntraLineChanges(lines)
+	return lines
+}
+
+// isWordDiff reports whether raw hunk lines are in word diff porcelain format.
+func isWordDiff(raw []string) bool {
+	for _, line := range raw {
+		if line == "~" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWordDiffLines groups word diff porcelain segments into lines. Each
+// "~" ends a line of the new and/or old file.
+func parseWordDiffLines(oldStart, newStart int, raw []string) []types.DiffLine {
+	var lines []types.DiffLine
+	var segments []types.WordSegment
+	oldNo, newNo := oldStart, newStart
+
+	for _, line := range raw {
+		if line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case '~':
+			lines = append(lines, wordDiffLine(segments, &oldNo, &newNo))
+			segments = nil
+		case '+':
+			segments = append(segments, types.WordSegment{Kind: types.LineAdd, Text: line[1:]})
+		case '-':
+			segments = append(segments, types.WordSegment{Kind: types.LineDelete, Text: line[1:]})
+		case '\\':
+			lines = append(lines, types.DiffLine{Kind: types.LineNoNewline, Content: strings.TrimSpace(line[1:])})
+		default:
+			segments = append(segments, types.WordSegment{Kind: types.LineContext, Text: line[1:]})
+		}
+	}
+	if len(segments) > 0 {
+		lines = append(lines, wordDiffLine(segments, &oldNo, &newNo))
+	}
+
+	return lines
+}
+
+// wordDiffLine builds a line from its segments and advances the line
+// counters of the sides it appears on.
+func wordDiffLine(segments []types.WordSegment, oldNo, newNo *int) types.DiffLine {
+	var oldText, newText strings.Builder
+	hasAdd, hasDelete, hasContext := false, false, len(segments) == 0
+
+	for _, seg := range segments {
+		switch seg.Kind {
+		case types.LineAdd:
+			hasAdd = true
+			newText.WriteString(seg.Text)
+		case types.LineDelete:
+			hasDelete = true
+			oldText.WriteString(seg.Text)
+		default:
+			hasContext = true
+			oldText.WriteString(seg.Text)
+			newText.WriteString(seg.Text)
+		}
+	}
+
+	line := types.DiffLine{Content: newText.String(), Segments: segments}
+	switch {
+	case hasContext && !hasAdd && !hasDelete:
+		line.Kind = types.LineContext
+	case hasAdd && !hasDelete && !hasContext:
+		line.Kind = types.LineAdd
+	case hasDelete && !hasAdd && !hasContext:
+		line.Kind = types.LineDelete
+		line.Content = oldText.String()
+	default:
+		line.Kind = types.LineModified
+	}
+
+	if line.Kind != types.LineAdd {
+		line.OldLineNo = *oldNo
+		*oldNo++
+	}
+	if line.Kind != types.LineDelete {
+		line.NewLineNo = *newNo
+		*newNo++
+	}
+	return line
+}
+
 // ExtractCommitSHA extracts the short commit SHA from git commit output.
 func ExtractCommitSHA(output string) string {
 	// git commit output typically contains "[branch SHA] message"
@@ -180,3 +532,16 @@ func ExtractCommitSHA(output string) string {
 	}
 	return ""
 }
+
+// ParsePathList parses NUL-separated paths, as printed by the -z option of
+// commands such as `git ls-files` and `git diff --name-only`. Unlike the
+// default output the paths are not quoted.
+func ParsePathList(output string) []string {
+	paths := []string{}
+	for _, path := range strings.Split(output, "\x00") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// FormatPatch renders hunks of a single file back into a unified diff that
+// `git apply` accepts.
+func FormatPatch(path string, hunks []types.DiffHunk) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+
+	for _, hunk := range hunks {
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", hunk.OldStart, hunk.OldLines, hunk.NewStart, hunk.NewLines)
+		for _, line := range hunk.Lines {
+			switch line.Kind {
+			case types.LineAdd:
+				b.WriteString("+")
+			case types.LineDelete:
+				b.WriteString("-")
+			case types.LineNoNewline:
+				b.WriteString("\\ ")
+			default:
+				b.WriteString(" ")
+			}
+			b.WriteString(line.Content)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// SelectHunkLines narrows a hunk to the changed lines at the given indexes
+// so that the result can be reverse-applied to the new side of the diff.
+// Unselected added lines stay in the new side and become context, while
+// unselected deleted lines are absent from it and are dropped.
+func SelectHunkLines(hunk types.DiffHunk, selected []int) (types.DiffHunk, error) {
+	keep := make(map[int]bool, len(selected))
+	for _, idx := range selected {
+		if idx < 0 || idx >= len(hunk.Lines) {
+			return types.DiffHunk{}, fmt.Errorf("line index %d out of range", idx)
+		}
+		keep[idx] = true
+	}
+
+	partial := types.DiffHunk{
+		OldStart: hunk.OldStart,
+		NewStart: hunk.NewStart,
+		Lines:    []types.DiffLine{},
+	}
+	dropped := false
+
+	for i, line := range hunk.Lines {
+		switch line.Kind {
+		case types.LineAdd:
+			if !keep[i] {
+				line.Kind = types.LineContext
+			}
+		case types.LineDelete:
+			if !keep[i] {
+				dropped = true
+				continue
+			}
+		case types.LineNoNewline:
+			// The marker belongs to the line before it; drop it with that line.
+			if dropped {
+				continue
+			}
+		}
+		dropped = false
+		partial.Lines = append(partial.Lines, line)
+
+		switch line.Kind {
+		case types.LineAdd:
+			partial.NewLines++
+		case types.LineDelete:
+			partial.OldLines++
+		case types.LineContext:
+			partial.OldLines++
+			partial.NewLines++
+		}
+	}
+
+	return partial, nil
+}
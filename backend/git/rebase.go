@@ -0,0 +1,97 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// FormatRebaseTodo renders steps as an interactive rebase todo list.
+func FormatRebaseTodo(steps []types.RebaseStep) string {
+	var b strings.Builder
+	for _, step := range steps {
+		if step.Action == types.RebaseExec {
+			fmt.Fprintf(&b, "exec %s\n", step.Command)
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s %s\n", step.Action, step.SHA, step.Subject)
+	}
+	return b.String()
+}
+
+// RebaseMessages maps the SHA of each step at which git opens the commit
+// message editor to the message to write there. Rewords are edited at their
+// own step; a chain of squashes and fixups is edited once, at its last
+// step, and takes the last message set on one of its squashes.
+func RebaseMessages(steps []types.RebaseStep) map[string]string {
+	messages := map[string]string{}
+	chainMessage := ""
+	for i, step := range steps {
+		switch step.Action {
+		case types.RebaseReword:
+			if step.Message != "" {
+				messages[step.SHA] = step.Message
+			}
+		case types.RebaseSquash:
+			if step.Message != "" {
+				chainMessage = step.Message
+			}
+		}
+
+		if step.Action != types.RebaseSquash && step.Action != types.RebaseFixup {
+			continue
+		}
+		if next := i + 1; next < len(steps) &&
+			(steps[next].Action == types.RebaseSquash || steps[next].Action == types.RebaseFixup) {
+			continue
+		}
+		if chainMessage != "" {
+			messages[step.SHA] = chainMessage
+		}
+		chainMessage = ""
+	}
+	return messages
+}
+
+// ParseTodoLine splits a line of a rebase todo or done file into its action
+// and argument, which is the commit SHA for commit steps and the command for
+// exec steps. Short action names such as "p" are expanded.
+func ParseTodoLine(line string) (types.RebaseAction, string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", ""
+	}
+
+	name, rest, _ := strings.Cut(line, " ")
+	action := types.RebaseAction(name)
+	switch name {
+	case "p":
+		action = types.RebasePick
+	case "r":
+		action = types.RebaseReword
+	case "e":
+		action = types.RebaseEdit
+	case "s":
+		action = types.RebaseSquash
+	case "f":
+		action = types.RebaseFixup
+	case "d":
+		action = types.RebaseDrop
+	case "x":
+		action = types.RebaseExec
+	}
+
+	if action == types.RebaseExec {
+		return action, strings.TrimSpace(rest)
+	}
+
+	// fixup may carry -C or -c before the commit
+	fields := strings.Fields(rest)
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "-") {
+			return action, field
+		}
+	}
+	return action, ""
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// SearchCommitFormat is the `git log --format` that ParseSearchCommits
+// expects, combined with -z and optionally --name-only.
+const SearchCommitFormat = "--format=%x1e%H%x00%an%x00%ae%x00%at%x00%s%x00"
+
+// ParseSearchCommits parses `git log -z` output in SearchCommitFormat.
+func ParseSearchCommits(output string) []types.SearchCommit {
+	commits := []types.SearchCommit{}
+	for _, chunk := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(chunk, "\x00", 6)
+		if len(fields) != 6 {
+			continue
+		}
+
+		authorTime, _ := strconv.ParseInt(fields[3], 10, 64)
+		commit := types.SearchCommit{
+			SHA:         fields[0],
+			Author:      fields[1],
+			AuthorEmail: fields[2],
+			AuthorTime:  authorTime,
+			Subject:     fields[4],
+			Files:       []string{},
+		}
+		for _, name := range strings.Split(fields[5], "\x00") {
+			if name = strings.Trim(name, "\n"); name != "" {
+				commit.Files = append(commit.Files, name)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// ParseGrep parses `git grep -n -z --column` output. Matched lines carry a
+// column and context lines do not, which tells them apart. A prefix, such
+// as the "<rev>:" git grep puts before paths when searching a revision, is
+// removed from every path. Context lines are attached to the match they
+// follow when within context lines of it, and to the next match otherwise.
+func ParseGrep(output, prefix string, context int) []types.ContentMatch {
+	matches := []types.ContentMatch{}
+	var pending []string
+	pendingPath := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) < 3 {
+			continue
+		}
+		path := strings.TrimPrefix(fields[0], prefix)
+		number, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+
+		if len(fields) == 4 {
+			column, _ := strconv.Atoi(fields[2])
+			match := types.ContentMatch{
+				Path:       path,
+				LineNumber: number,
+				Column:     column,
+				Content:    fields[3],
+				Before:     []string{},
+				After:      []string{},
+			}
+			if pendingPath == path {
+				match.Before = pending
+			}
+			matches = append(matches, match)
+			pending, pendingPath = nil, ""
+			continue
+		}
+
+		if last := len(matches) - 1; last >= 0 && matches[last].Path == path &&
+			number-matches[last].LineNumber <= context && len(pending) == 0 {
+			matches[last].After = append(matches[last].After, fields[2])
+			continue
+		}
+		if pendingPath != path {
+			pending, pendingPath = []string{}, path
+		}
+		pending = append(pending, fields[2])
+	}
+	return matches
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// TreeEntry is a single entry of `git ls-tree` output.
+type TreeEntry struct {
+	Mode string
+	Type string
+	SHA  string
+	Name string
+}
+
+// ParseTreeEntries parses the output of `git ls-tree`, with or without -z.
+func ParseTreeEntries(output string) []TreeEntry {
+	var entries []TreeEntry
+	separator := "\n"
+	if strings.Contains(output, "\x00") {
+		separator = "\x00"
+	}
+	for _, line := range strings.Split(strings.TrimRight(output, separator), separator) {
+		meta, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 {
+			continue
+		}
+		entries = append(entries, TreeEntry{Mode: fields[0], Type: fields[1], SHA: fields[2], Name: name})
+	}
+	return entries
+}
+
+// ParseSnapshots parses `git for-each-ref` output in the format
+// "%(refname:lstrip=3)%00%(creatordate:unix)%00%(contents:subject)%00%(contents:body)%1e",
+// where the body lists one snapshotted path per line.
+func ParseSnapshots(output string) []types.Snapshot {
+	snapshots := []types.Snapshot{}
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x00")
+		if len(fields) != 4 {
+			continue
+		}
+
+		created, _ := strconv.ParseInt(fields[1], 10, 64)
+		snapshot := types.Snapshot{
+			ID:        fields[0],
+			CreatedAt: created,
+			Reason:    fields[2],
+			Paths:     []string{},
+		}
+		for _, path := range strings.Split(fields[3], "\n") {
+			if path != "" {
+				snapshot.Paths = append(snapshot.Paths, path)
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// ParseNumstat parses `git diff --numstat -z` output. Renamed files are
+// printed with an empty path followed by the old and new paths, and binary
+// files with "-" instead of line counts.
+func ParseNumstat(output string) types.DiffStat {
+	stat := types.DiffStat{Files: []types.FileStat{}}
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(strings.TrimLeft(fields[i], "\n"), "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		file := types.FileStat{Path: parts[2]}
+		if file.Path == "" && i+2 < len(fields) {
+			file.OldPath, file.Path = fields[i+1], fields[i+2]
+			i += 2
+		}
+		if parts[0] == "-" && parts[1] == "-" {
+			file.Binary = true
+		} else {
+			file.Insertions, _ = strconv.Atoi(parts[0])
+			file.Deletions, _ = strconv.Atoi(parts[1])
+		}
+
+		stat.Files = append(stat.Files, file)
+		stat.Insertions += file.Insertions
+		stat.Deletions += file.Deletions
+	}
+	stat.FilesChanged = len(stat.Files)
+	return stat
+}
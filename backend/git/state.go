@@ -0,0 +1,141 @@
+package git
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// ReadHeadState derives the HEAD state from the files in a git directory,
+// as returned by `git rev-parse --absolute-git-dir`.
+func ReadHeadState(gitDir string) (*types.HeadState, error) {
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return nil, err
+	}
+
+	state := &types.HeadState{}
+	content := strings.TrimSpace(string(head))
+
+	if ref, ok := strings.CutPrefix(content, "ref: "); ok {
+		state.Branch = strings.TrimPrefix(ref, "refs/heads/")
+		state.Unborn = !refExists(commonDir(gitDir), ref)
+	} else {
+		state.Detached = true
+		state.DetachedAt = shortSHA(content)
+	}
+
+	readOperation(gitDir, state)
+	return state, nil
+}
+
+// readOperation fills in the operation in progress, if any.
+func readOperation(gitDir string, state *types.HeadState) {
+	if dir := filepath.Join(gitDir, "rebase-merge"); isDir(dir) {
+		state.Operation = types.OperationRebase
+		state.Step = readInt(filepath.Join(dir, "msgnum"))
+		state.TotalSteps = readInt(filepath.Join(dir, "end"))
+		setRebasedBranch(dir, state)
+		return
+	}
+	if dir := filepath.Join(gitDir, "rebase-apply"); isDir(dir) {
+		state.Operation = types.OperationRebase
+		state.Step = readInt(filepath.Join(dir, "next"))
+		state.TotalSteps = readInt(filepath.Join(dir, "last"))
+		setRebasedBranch(dir, state)
+		return
+	}
+
+	switch {
+	case fileExists(filepath.Join(gitDir, "CHERRY_PICK_HEAD")):
+		state.Operation = types.OperationCherryPick
+	case fileExists(filepath.Join(gitDir, "REVERT_HEAD")):
+		state.Operation = types.OperationRevert
+	case fileExists(filepath.Join(gitDir, "MERGE_HEAD")):
+		state.Operation = types.OperationMerge
+	case fileExists(filepath.Join(gitDir, "BISECT_LOG")):
+		state.Operation = types.OperationBisect
+	}
+}
+
+// setRebasedBranch records the branch being rebased from a rebase state dir.
+func setRebasedBranch(dir string, state *types.HeadState) {
+	data, err := os.ReadFile(filepath.Join(dir, "head-name"))
+	if err != nil {
+		return
+	}
+	name := strings.TrimSpace(string(data))
+	if name != "" && name != "detached HEAD" {
+		state.Branch = strings.TrimPrefix(name, "refs/heads/")
+	}
+}
+
+// commonDir returns the directory holding refs, which differs from the
+// git directory for linked worktrees.
+func commonDir(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	dir := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(gitDir, dir)
+	}
+	return dir
+}
+
+// refExists reports whether ref exists as a loose or packed ref.
+func refExists(dir, ref string) bool {
+	if fileExists(filepath.Join(dir, filepath.FromSlash(ref))) {
+		return true
+	}
+	// reftable repositories cannot be inspected through plain files.
+	if isDir(filepath.Join(dir, "reftable")) {
+		return true
+	}
+
+	f, err := os.Open(filepath.Join(dir, "packed-refs"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == ref {
+			return true
+		}
+	}
+	return false
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func readInt(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return n
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// TagFormat is the `git for-each-ref --format` that ParseTags expects.
+const TagFormat = "--format=%(refname:lstrip=2)%00%(objecttype)%00%(objectname)%00%(*objectname)%00" +
+	"%(taggername)%00%(taggeremail:trim)%00%(creatordate:unix)%00" +
+	"%(contents:subject)%00%(contents:body)%00%(contents:signature)%1e"
+
+// ParseTags parses `git for-each-ref refs/tags` output in TagFormat.
+func ParseTags(output string) []types.Tag {
+	tags := []types.Tag{}
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x00")
+		if len(fields) != 10 {
+			continue
+		}
+
+		date, _ := strconv.ParseInt(fields[6], 10, 64)
+		tag := types.Tag{Name: fields[0], Target: fields[2], Date: date}
+		if fields[1] == "tag" {
+			tag.Annotated = true
+			tag.Target = fields[3]
+			tag.Signed = fields[9] != ""
+			tag.Tagger = fields[4]
+			tag.TaggerEmail = fields[5]
+			tag.Message = fields[7]
+			if body := strings.TrimRight(fields[8], "\n"); body != "" {
+				tag.Message += "\n\n" + body
+			}
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,44 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"git-gui/backend/git"
+	"git-gui/backend/graph"
+	"git-gui/backend/types"
+)
+
+// GetCommitGraph returns up to limit rows of the commit graph of all
+// branches, remote branches and tags, starting at row offset. Pages are
+// cheapest when loaded in order: the layout of the previous page is kept
+// and continued, while any other offset lays out the graph from the top.
+func (a *App) GetCommitGraph(offset, limit int) (*types.GraphPage, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if offset < 0 || limit < 1 {
+		return nil, fmt.Errorf("invalid graph window offset=%d limit=%d", offset, limit)
+	}
+
+	if offset == 0 || a.graph == nil || a.graph.Len() != offset {
+		a.graph = graph.New()
+	}
+	start := a.graph.Len()
+	count := offset + limit - start
+
+	output, err := a.executor.Execute("log", "--topo-order", git.GraphCommitFormat,
+		fmt.Sprintf("--skip=%d", start), fmt.Sprintf("-n%d", count),
+		"--branches", "--remotes", "--tags", "HEAD", "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load commit graph: %w", err)
+	}
+
+	commits := git.ParseGraphCommits(output)
+	rows := a.graph.Add(commits)
+	return &types.GraphPage{
+		Offset:  offset,
+		Rows:    rows[min(offset-start, len(rows)):],
+		HasMore: len(commits) == count,
+	}, nil
+}
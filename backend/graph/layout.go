@@ -0,0 +1,134 @@
+package graph
+
+import "git-gui/backend/types"
+
+// Layout assigns commits to the columns of a commit graph. Commits must be
+// added children first, as git log --topo-order lists them. The layout
+// keeps the lanes still open after the last commit, so rows added for a
+// later page continue the lines of earlier ones.
+//
+// Columns are stable: a lane keeps its column until the commit it waits
+// for is reached, and freed columns are reused by new lanes.
+type Layout struct {
+	lanes     []lane
+	nextColor int
+	rows      int
+}
+
+// lane is a column waiting for the commit sha. from lists the columns of
+// the previous row its lines start at, more than one when a merge line
+// joins the lane.
+type lane struct {
+	sha   string
+	color int
+	from  []int
+}
+
+// New returns an empty layout.
+func New() *Layout {
+	return &Layout{}
+}
+
+// Len returns the number of rows laid out so far.
+func (l *Layout) Len() int {
+	return l.rows
+}
+
+// Add lays out commits below the rows added before and returns their rows.
+func (l *Layout) Add(commits []types.GraphCommit) []types.GraphRow {
+	rows := make([]types.GraphRow, 0, len(commits))
+	for _, commit := range commits {
+		rows = append(rows, l.add(commit))
+	}
+	return rows
+}
+
+func (l *Layout) add(commit types.GraphCommit) types.GraphRow {
+	row := types.GraphRow{
+		Commit: commit,
+		Column: l.find(commit.SHA),
+		Edges:  []types.GraphEdge{},
+		Merge:  len(commit.Parents) > 1,
+	}
+	if row.Column == -1 {
+		// Nothing leads here yet: the commit is the tip of a branch.
+		row.Column = l.allocate(commit.SHA, nil)
+	}
+	row.Color = l.lanes[row.Column].color
+
+	// Draw the lines coming in from the previous row. Every lane waiting
+	// for this commit ends at its node.
+	incoming := 0
+	for i, ln := range l.lanes {
+		if ln.sha == "" {
+			continue
+		}
+		to := i
+		if ln.sha == commit.SHA {
+			to = row.Column
+			incoming += len(ln.from)
+		}
+		for _, from := range ln.from {
+			row.Edges = append(row.Edges, types.GraphEdge{From: from, To: to, Color: ln.color})
+		}
+	}
+	row.Fork = incoming > 1
+
+	for i := range l.lanes {
+		if l.lanes[i].sha == commit.SHA {
+			l.lanes[i] = lane{}
+		} else if l.lanes[i].sha != "" {
+			l.lanes[i].from = []int{i}
+		}
+	}
+
+	// The first parent continues the commit's lane. Other parents join a
+	// lane already waiting for them, or open a new one.
+	if len(commit.Parents) > 0 {
+		l.lanes[row.Column] = lane{sha: commit.Parents[0], color: row.Color, from: []int{row.Column}}
+	}
+	for _, parent := range commit.Parents[min(1, len(commit.Parents)):] {
+		if i := l.find(parent); i != -1 {
+			l.lanes[i].from = append(l.lanes[i].from, row.Column)
+			continue
+		}
+		l.allocate(parent, []int{row.Column})
+	}
+
+	for len(l.lanes) > 0 && l.lanes[len(l.lanes)-1].sha == "" {
+		l.lanes = l.lanes[:len(l.lanes)-1]
+	}
+
+	row.Width = row.Column + 1
+	for _, edge := range row.Edges {
+		row.Width = max(row.Width, edge.From+1, edge.To+1)
+	}
+
+	l.rows++
+	return row
+}
+
+// find returns the first column waiting for sha, or -1.
+func (l *Layout) find(sha string) int {
+	for i, ln := range l.lanes {
+		if ln.sha == sha {
+			return i
+		}
+	}
+	return -1
+}
+
+// allocate opens a lane for sha in the first free column and gives it a
+// new color.
+func (l *Layout) allocate(sha string, from []int) int {
+	ln := lane{sha: sha, color: l.nextColor, from: from}
+	l.nextColor++
+	for i := range l.lanes {
+		if l.lanes[i].sha == "" {
+			l.lanes[i] = ln
+			return i
+		}
+	}
+	l.lanes = append(l.lanes, ln)
+	return len(l.lanes) - 1
+}
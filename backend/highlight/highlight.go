@@ -0,0 +1,129 @@
+package highlight
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"git-gui/backend/types"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// MaxSourceSize is the largest file, in bytes, that is tokenized. Bigger
+// files are returned without highlighting to keep diffs responsive.
+const MaxSourceSize = 1 << 20
+
+// DetectLanguage returns the name of the language of path. A language set
+// through the linguist-language git attribute takes precedence over the
+// file extension. An empty string means the language is unknown.
+func DetectLanguage(path, linguistLanguage string) string {
+	var lexer chroma.Lexer
+	if linguistLanguage != "" {
+		lexer = lexers.Get(linguistLanguage)
+	}
+	if lexer == nil {
+		lexer = lexers.Match(path)
+	}
+	if lexer == nil {
+		return ""
+	}
+	return lexer.Config().Name
+}
+
+// TokenizeLines highlights a whole file and returns the token spans of each
+// of its lines. Tokenizing the full file keeps multi-line constructs such
+// as block comments and raw strings correct on every line.
+func TokenizeLines(language, source string) ([][]types.TokenSpan, error) {
+	lexer := lexers.Get(language)
+	if lexer == nil || source == "" || len(source) > MaxSourceSize {
+		return nil, nil
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, source)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := [][]types.TokenSpan{nil}
+	col := 0
+	for _, tok := range iterator.Tokens() {
+		class := tokenClass(tok.Type)
+		parts := strings.Split(tok.Value, "\n")
+		for i, part := range parts {
+			if i > 0 {
+				lines = append(lines, nil)
+				col = 0
+			}
+			width := utf8.RuneCountInString(part)
+			if width == 0 {
+				continue
+			}
+			if class != "" {
+				last := len(lines) - 1
+				lines[last] = append(lines[last], types.TokenSpan{Start: col, End: col + width, Class: class})
+			}
+			col += width
+		}
+	}
+
+	return lines, nil
+}
+
+// HighlightDiff maps the tokens of the old and new versions of a file onto
+// the lines of its diff. Deleted lines take their tokens from the old file
+// and all other lines from the new one.
+func HighlightDiff(result *types.DiffResult, language, oldSource, newSource string) (*types.DiffHighlight, error) {
+	highlight := &types.DiffHighlight{
+		FilePath: result.FilePath,
+		Language: language,
+		Hunks:    make([]types.HunkHighlight, len(result.Hunks)),
+	}
+	if language == "" || result.IsBinary {
+		return highlight, nil
+	}
+
+	oldLines, err := TokenizeLines(language, oldSource)
+	if err != nil {
+		return nil, err
+	}
+	newLines, err := TokenizeLines(language, newSource)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, hunk := range result.Hunks {
+		lines := make([]types.LineHighlight, len(hunk.Lines))
+		for j, line := range hunk.Lines {
+			switch line.Kind {
+			case types.LineDelete:
+				lines[j].Tokens = lineTokens(oldLines, line.OldLineNo)
+			case types.LineNoNewline:
+			default:
+				lines[j].Tokens = lineTokens(newLines, line.NewLineNo)
+			}
+		}
+		highlight.Hunks[i].Lines = lines
+	}
+
+	return highlight, nil
+}
+
+// lineTokens returns the tokens of a 1-based line number.
+func lineTokens(lines [][]types.TokenSpan, lineNo int) []types.TokenSpan {
+	if lineNo < 1 || lineNo > len(lines) {
+		return nil
+	}
+	return lines[lineNo-1]
+}
+
+// tokenClass returns the short CSS class chroma uses for a token type, as
+// in its HTML formatter. Plain text gets no class.
+func tokenClass(tokenType chroma.TokenType) string {
+	for t := tokenType; t != chroma.Text && t != chroma.None; t = t.Parent() {
+		if class, ok := chroma.StandardTypes[t]; ok && class != "" {
+			return class
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// GetFileHistory returns up to limit commits that changed path, newest first
+// and skipping the first offset, following the file across renames. Each
+// entry carries the file's name in that commit and the diff of the change.
+func (a *App) GetFileHistory(path string, limit, offset int) ([]types.FileHistoryEntry, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if path == "" {
+		return nil, errors.New("file path required")
+	}
+	if offset < 0 || limit < 1 {
+		return nil, fmt.Errorf("invalid history window offset=%d limit=%d", offset, limit)
+	}
+
+	args := []string{"log", "--follow", "-M", git.FileHistoryFormat, "--raw", "-p", "-z"}
+	args = append(args, diffOptionArgs(a.diffOptions)...)
+	// git log --follow loses commits when --skip is combined with -n, so
+	// the skipped commits are dropped here instead.
+	args = append(args, fmt.Sprintf("-n%d", offset+limit), "--", path)
+
+	output, err := a.executor.Execute(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of %s: %w", path, err)
+	}
+
+	records, err := git.ParseFileHistory(path, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse history of %s: %w", path, err)
+	}
+
+	history := []types.FileHistoryEntry{}
+	for _, record := range records[min(offset, len(records)):] {
+		entry := record.Entry
+		entry.Diff, err = a.parseDiffOutput(entry.Path, record.Diff, true)
+		if err != nil {
+			return nil, err
+		}
+		entry.Diff.Mode = types.DiffRevisions
+		entry.Diff.Diff = ""
+		history = append(history, entry)
+	}
+	return history, nil
+}
@@ -0,0 +1,427 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// journalFile is the file in the git directory the journal is saved to, so
+// that operations can still be undone after the repository is reopened.
+const journalFile = "gitgui-journal.json"
+
+// journalRefPrefix is the ref namespace that keeps the commits and index
+// trees of journal entries reachable, and so safe from garbage collection,
+// for as long as the entries are in the journal.
+const journalRefPrefix = "refs/gitgui/journal/"
+
+// maxJournalEntries bounds the saved history; the oldest entries are dropped
+// first.
+const maxJournalEntries = 100
+
+// journal is the history of mutating App calls. Entries before position are
+// applied; entries from position on have been undone and can be redone until
+// a new operation is recorded. A journal without a path is kept in memory
+// only.
+type journal struct {
+	entries   []types.JournalEntry
+	position  int
+	nextID    int
+	recording bool
+	path      string
+}
+
+// savedJournal is the form in which a journal is written to its file.
+type savedJournal struct {
+	Entries  []types.JournalEntry `json:"Entries"`
+	Position int                  `json:"Position"`
+	NextID   int                  `json:"NextID"`
+}
+
+// worktreeChange describes working tree files an operation changed and the
+// snapshot holding their previous content.
+type worktreeChange struct {
+	paths      []string
+	snapshotID string
+}
+
+// GetOperationHistory returns the journal of operations, newest first.
+// Entries that have been undone are marked and can be redone.
+func (a *App) GetOperationHistory() ([]types.JournalEntry, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	history := []types.JournalEntry{}
+	if a.journal == nil {
+		return history, nil
+	}
+	for i := len(a.journal.entries) - 1; i >= 0; i-- {
+		entry := a.journal.entries[i]
+		entry.Undone = i >= a.journal.position
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// Undo reverts the most recent operation that has not been undone, provided
+// the repository has not changed since. It returns the undone entry.
+func (a *App) Undo() (*types.JournalEntry, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if a.journal == nil || a.journal.position == 0 {
+		return nil, errors.New("nothing to undo")
+	}
+
+	entry := &a.journal.entries[a.journal.position-1]
+	current, err := a.captureState(slices.Collect(maps.Keys(entry.After.Refs)))
+	if err != nil {
+		return nil, err
+	}
+	if !sameState(current, entry.After) {
+		return nil, fmt.Errorf("cannot undo %s: the repository has changed since", entry.Operation)
+	}
+
+	if len(entry.Paths) > 0 && entry.After.SnapshotID == "" {
+		if entry.After.SnapshotID, err = a.snapshotFiles("redo "+entry.Operation, entry.Paths); err != nil {
+			return nil, fmt.Errorf("failed to snapshot %s: %w", strings.Join(entry.Paths, ", "), err)
+		}
+	}
+
+	if err := a.applyState(entry.Before, current, entry.Paths); err != nil {
+		return nil, fmt.Errorf("failed to undo %s: %w", entry.Operation, err)
+	}
+
+	a.journal.position--
+	a.saveJournal(nil, nil)
+	undone := *entry
+	undone.Undone = true
+	return &undone, nil
+}
+
+// Redo reapplies the most recently undone operation, provided the
+// repository has not changed since it was undone. It returns the entry.
+func (a *App) Redo() (*types.JournalEntry, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if a.journal == nil || a.journal.position == len(a.journal.entries) {
+		return nil, errors.New("nothing to redo")
+	}
+
+	entry := &a.journal.entries[a.journal.position]
+	current, err := a.captureState(slices.Collect(maps.Keys(entry.Before.Refs)))
+	if err != nil {
+		return nil, err
+	}
+	if !sameState(current, entry.Before) {
+		return nil, fmt.Errorf("cannot redo %s: the repository has changed since", entry.Operation)
+	}
+
+	if err := a.applyState(entry.After, current, entry.Paths); err != nil {
+		return nil, fmt.Errorf("failed to redo %s: %w", entry.Operation, err)
+	}
+
+	a.journal.position++
+	a.saveJournal(nil, nil)
+	redone := *entry
+	return &redone, nil
+}
+
+// journaled runs fn and records it in the journal. refs lists the refs fn
+// may create, move or delete; "HEAD" stands for the branch HEAD points to.
+func (a *App) journaled(operation string, refs []string, fn func() error) error {
+	return a.record(operation, refs, func() (*worktreeChange, error) {
+		return nil, fn()
+	})
+}
+
+// record runs fn and, if it succeeds and changed anything, adds an entry with
+// the repository state before and after it to the journal. Calls made while
+// another operation is being recorded become part of that operation.
+func (a *App) record(operation string, refs []string, fn func() (*worktreeChange, error)) error {
+	if a.journal == nil || a.journal.recording {
+		_, err := fn()
+		return err
+	}
+
+	before, err := a.captureState(refs)
+	if err != nil {
+		return err
+	}
+
+	a.journal.recording = true
+	change, err := fn()
+	a.journal.recording = false
+	if err != nil {
+		return err
+	}
+
+	after, err := a.captureState(refs)
+	if err != nil {
+		return err
+	}
+
+	entry := types.JournalEntry{
+		Operation: operation,
+		Timestamp: time.Now().Unix(),
+		Before:    *before,
+		After:     *after,
+	}
+	if change != nil {
+		entry.Paths = change.paths
+		entry.Before.SnapshotID = change.snapshotID
+	}
+	if len(entry.Paths) == 0 && sameState(before, entry.After) {
+		return nil
+	}
+
+	a.journal.nextID++
+	entry.ID = a.journal.nextID
+	entry.Description = describeChange(entry)
+
+	dropped := slices.Clone(a.journal.entries[a.journal.position:])
+	a.journal.entries = append(a.journal.entries[:a.journal.position], entry)
+	if excess := len(a.journal.entries) - maxJournalEntries; excess > 0 {
+		dropped = append(dropped, a.journal.entries[:excess]...)
+		a.journal.entries = slices.Clone(a.journal.entries[excess:])
+	}
+	a.journal.position = len(a.journal.entries)
+	a.saveJournal([]types.JournalEntry{entry}, dropped)
+	return nil
+}
+
+// loadJournal reads the journal saved in gitDir. A missing or unreadable
+// file starts an empty journal, which replaces the file on the next save.
+func loadJournal(gitDir string) *journal {
+	j := &journal{path: filepath.Join(gitDir, journalFile)}
+	data, err := os.ReadFile(j.path)
+	if err != nil {
+		return j
+	}
+
+	var saved savedJournal
+	if err := json.Unmarshal(data, &saved); err != nil || saved.Position < 0 || saved.Position > len(saved.Entries) {
+		return j
+	}
+	j.entries, j.position, j.nextID = saved.Entries, saved.Position, saved.NextID
+	return j
+}
+
+// saveJournal writes the journal to its file and moves the refs that keep
+// the objects of added entries reachable off the dropped ones. The operation
+// is applied by the time this runs, so a journal that cannot be saved is
+// not an error; its history is only lost when the repository is reopened.
+func (a *App) saveJournal(added, dropped []types.JournalEntry) {
+	if a.journal.path == "" {
+		return
+	}
+
+	var updates strings.Builder
+	for _, entry := range dropped {
+		for _, sha := range entryObjects(entry) {
+			fmt.Fprintf(&updates, "delete %s%d/%s\n", journalRefPrefix, entry.ID, sha)
+		}
+	}
+	for _, entry := range added {
+		for _, sha := range entryObjects(entry) {
+			fmt.Fprintf(&updates, "update %s%d/%s %s\n", journalRefPrefix, entry.ID, sha, sha)
+		}
+	}
+	if updates.Len() > 0 {
+		_, _ = a.executor.ExecuteWithInput(updates.String(), "update-ref", "--stdin")
+	}
+
+	data, err := json.Marshal(savedJournal{
+		Entries:  a.journal.entries,
+		Position: a.journal.position,
+		NextID:   a.journal.nextID,
+	})
+	if err == nil {
+		_ = os.WriteFile(a.journal.path, data, 0o644)
+	}
+}
+
+// entryObjects returns the commits and trees an entry can restore, once
+// each and in a stable order.
+func entryObjects(entry types.JournalEntry) []string {
+	var shas []string
+	for _, state := range []types.RepoState{entry.Before, entry.After} {
+		shas = append(shas, state.HeadSHA, state.IndexTree)
+		for _, ref := range slices.Sorted(maps.Keys(state.Refs)) {
+			shas = append(shas, state.Refs[ref])
+		}
+	}
+
+	var objects []string
+	for _, sha := range shas {
+		if sha != "" && !slices.Contains(objects, sha) {
+			objects = append(objects, sha)
+		}
+	}
+	return objects
+}
+
+// captureState reads HEAD, the index tree and the given refs.
+func (a *App) captureState(refs []string) (*types.RepoState, error) {
+	state := &types.RepoState{Refs: map[string]string{}}
+
+	head, err := a.executor.Execute("symbolic-ref", "-q", "HEAD")
+	var gitErr *git.GitError
+	if err != nil && !(errors.As(err, &gitErr) && gitErr.ExitCode == 1) {
+		return nil, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	state.Head = strings.TrimSpace(head)
+
+	if state.HeadSHA, err = a.resolveRef("HEAD"); err != nil {
+		return nil, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	for _, ref := range refs {
+		if state.Refs[ref], err = a.resolveRef(ref); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", ref, err)
+		}
+	}
+
+	// An index with unresolved conflicts has no tree; it is left alone.
+	if tree, err := a.executor.Execute("write-tree"); err == nil {
+		state.IndexTree = strings.TrimSpace(tree)
+	}
+
+	return state, nil
+}
+
+// applyState moves the repository from current to target. Refs are created
+// or moved before HEAD is switched and deleted afterwards, so HEAD never
+// points at a missing branch.
+func (a *App) applyState(target types.RepoState, current *types.RepoState, paths []string) error {
+	for _, ref := range slices.Sorted(maps.Keys(target.Refs)) {
+		if sha := target.Refs[ref]; sha != "" && sha != current.Refs[ref] {
+			if _, err := a.executor.Execute("update-ref", ref, sha, current.Refs[ref]); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, headTracked := target.Refs["HEAD"]
+	if target.Head != current.Head || (target.Head == "" && !headTracked && target.HeadSHA != current.HeadSHA) {
+		args := []string{"checkout", "-q", strings.TrimPrefix(target.Head, "refs/heads/")}
+		if target.Head == "" {
+			args = []string{"checkout", "-q", "--detach", target.HeadSHA}
+		}
+		if _, err := a.executor.Execute(args...); err != nil {
+			return err
+		}
+		if a.repo != nil {
+			a.refreshHead()
+		}
+	}
+
+	for _, ref := range slices.Sorted(maps.Keys(target.Refs)) {
+		if target.Refs[ref] == "" && current.Refs[ref] != "" {
+			if _, err := a.executor.Execute("update-ref", "-d", ref, current.Refs[ref]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if target.IndexTree != "" && target.IndexTree != current.IndexTree {
+		if _, err := a.executor.Execute("read-tree", target.IndexTree); err != nil {
+			return err
+		}
+	}
+
+	if len(paths) > 0 {
+		return a.restoreWorktree(target.SnapshotID, paths)
+	}
+	return nil
+}
+
+// restoreWorktree makes paths match a snapshot: saved files are written back
+// and files missing from it are deleted.
+func (a *App) restoreWorktree(snapshotID string, paths []string) error {
+	var restored []string
+	if snapshotID != "" {
+		var err error
+		if restored, err = a.restoreSnapshot(snapshotID); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range paths {
+		if slices.Contains(restored, path) {
+			continue
+		}
+		fullPath, err := a.worktreePath(path)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveRef returns the SHA ref points to, or "" when it does not exist.
+func (a *App) resolveRef(ref string) (string, error) {
+	output, err := a.executor.Execute("rev-parse", "--verify", "-q", ref)
+	var gitErr *git.GitError
+	if errors.As(err, &gitErr) && gitErr.ExitCode == 1 {
+		return "", nil
+	}
+	return strings.TrimSpace(output), err
+}
+
+// sameState reports whether HEAD, the refs and the index of two states
+// match.
+func sameState(a *types.RepoState, b types.RepoState) bool {
+	return a.Head == b.Head && a.HeadSHA == b.HeadSHA && a.IndexTree == b.IndexTree && maps.Equal(a.Refs, b.Refs)
+}
+
+// describeChange summarises what an entry changed for the history view.
+func describeChange(entry types.JournalEntry) string {
+	var parts []string
+	if entry.Before.Head != entry.After.Head {
+		parts = append(parts, fmt.Sprintf("HEAD %s -> %s", shortHead(entry.Before), shortHead(entry.After)))
+	} else if entry.Before.HeadSHA != entry.After.HeadSHA {
+		parts = append(parts, fmt.Sprintf("%s %s -> %s", shortHead(entry.After), shortSHA(entry.Before.HeadSHA), shortSHA(entry.After.HeadSHA)))
+	}
+	for _, ref := range slices.Sorted(maps.Keys(entry.After.Refs)) {
+		if ref == "HEAD" || entry.Before.Refs[ref] == entry.After.Refs[ref] {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s -> %s", strings.TrimPrefix(ref, "refs/heads/"),
+			shortSHA(entry.Before.Refs[ref]), shortSHA(entry.After.Refs[ref])))
+	}
+	if entry.Before.IndexTree != entry.After.IndexTree {
+		parts = append(parts, "index changed")
+	}
+	if len(entry.Paths) > 0 {
+		parts = append(parts, strings.Join(entry.Paths, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func shortHead(state types.RepoState) string {
+	if state.Head == "" {
+		return shortSHA(state.HeadSHA)
+	}
+	return strings.TrimPrefix(state.Head, "refs/heads/")
+}
+
+func shortSHA(sha string) string {
+	if sha == "" {
+		return "(none)"
+	}
+	return sha[:min(7, len(sha))]
+}
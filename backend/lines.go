@@ -0,0 +1,95 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-gui/backend/types"
+)
+
+// indexRevision selects the staged version of a file in GetFileLines.
+const indexRevision = ":"
+
+// GetFileLines returns lines start through end of a file at revision so the
+// diff viewer can expand context around a hunk. An empty revision reads the
+// working tree and ":" reads the index. The range is clamped to the file.
+func (a *App) GetFileLines(path, revision string, start, end int) (*types.FileLines, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if start < 1 || end < start {
+		return nil, fmt.Errorf("invalid line range %d-%d", start, end)
+	}
+
+	content, err := a.readFileAt(path, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(content, "\n")
+	if strings.HasSuffix(content, "\n") {
+		lines = lines[:len(lines)-1]
+	}
+
+	result := &types.FileLines{
+		Path:       path,
+		Revision:   revision,
+		Start:      start,
+		End:        min(end, len(lines)),
+		TotalLines: len(lines),
+		Lines:      []string{},
+	}
+	if start <= len(lines) {
+		result.Lines = lines[start-1 : result.End]
+	}
+
+	return result, nil
+}
+
+// readFileAt returns the content of path in the working tree, the index or
+// a revision.
+func (a *App) readFileAt(path, revision string) (string, error) {
+	if revision == "" {
+		fullPath, err := a.worktreePath(path)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(fullPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return string(data), nil
+	}
+
+	object := ":" + path
+	if revision != indexRevision {
+		if err := checkRevision(revision); err != nil {
+			return "", err
+		}
+		object = revision + ":" + path
+	}
+
+	output, err := a.executor.Execute("cat-file", "blob", object)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s at %s: %w", path, revision, err)
+	}
+	return output, nil
+}
+
+// worktreePath resolves a repository-relative path, refusing paths that
+// escape the repository.
+func (a *App) worktreePath(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("path must be relative to the repository: %s", path)
+	}
+
+	clean := filepath.Clean(filepath.FromSlash(path))
+	if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path is outside the repository: %s", path)
+	}
+
+	return filepath.Join(a.repo.Path, clean), nil
+}
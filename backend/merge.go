@@ -0,0 +1,191 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// errMergeStopped reports that a merge stopped with conflicts.
+var errMergeStopped = errors.New("merge stopped with conflicts")
+
+// PreviewMerge predicts whether merging branch into HEAD would fast-forward,
+// merge cleanly or conflict, without touching the index or working tree.
+func (a *App) PreviewMerge(branch string) (*types.MergePreview, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	head, target, err := a.headAndRevision(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &types.MergePreview{
+		Branch:    branch,
+		HeadSHA:   head,
+		BranchSHA: target,
+		Conflicts: []string{},
+	}
+	if preview.Outcome, err = a.mergeShortcut(head, target); err != nil {
+		return nil, err
+	}
+	if preview.Outcome != "" {
+		return preview, nil
+	}
+
+	output, err := a.executor.Execute("merge-tree", "--write-tree", "-z", "--name-only", "--no-messages", head, target)
+	var gitErr *git.GitError
+	if errors.As(err, &gitErr) && gitErr.ExitCode == 1 {
+		output, err = gitErr.Output, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview merge of %s: %w", branch, err)
+	}
+
+	preview.Tree, preview.Conflicts = git.ParseMergeTree(output)
+	preview.Outcome = types.MergeCommitted
+	if len(preview.Conflicts) > 0 {
+		preview.Outcome = types.MergeConflict
+	}
+	return preview, nil
+}
+
+// Merge merges branch into the current branch. Conflicts are reported in
+// the result rather than as an error, leaving the merge in progress for
+// ContinueOperation or AbortOperation.
+func (a *App) Merge(branch string, options types.MergeOptions) (*types.MergeResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	args, err := mergeArgs(options)
+	if err != nil {
+		return nil, err
+	}
+
+	head, target, err := a.headAndRevision(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.MergeResult{Branch: branch, HeadSHA: head, Conflicts: []string{}}
+	shortcut, err := a.mergeShortcut(head, target)
+	if err != nil {
+		return nil, err
+	}
+	if shortcut == types.MergeUpToDate {
+		result.Outcome = shortcut
+		return result, nil
+	}
+	if shortcut != types.MergeFastForward && options.FastForward == types.FastForwardOnly {
+		return nil, fmt.Errorf("cannot fast-forward to %s", branch)
+	}
+
+	err = a.record("merge", []string{"HEAD"}, func() (*worktreeChange, error) {
+		_, err := a.executor.Execute(append(args, branch)...)
+		if err != nil {
+			// Squash merges stop on conflicts without MERGE_HEAD, so look
+			// at the index instead of the operation state.
+			output, listErr := a.executor.Execute("diff", "--name-only", "-z", "--diff-filter=U")
+			if conflicts := git.ParsePathList(output); listErr == nil && len(conflicts) > 0 {
+				result.Conflicts = conflicts
+				return nil, errMergeStopped
+			}
+			return nil, fmt.Errorf("failed to merge %s: %w", branch, err)
+		}
+		return a.revisionChange("merge", head)
+	})
+	if errors.Is(err, errMergeStopped) {
+		result.Outcome = types.MergeConflict
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result.HeadSHA, err = a.resolveRef("HEAD"); err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	switch {
+	case options.Squash:
+		result.Outcome = types.MergeSquashed
+	case result.HeadSHA == target:
+		result.Outcome = types.MergeFastForward
+	default:
+		result.Outcome = types.MergeCommitted
+	}
+	return result, nil
+}
+
+// mergeArgs converts options into a git merge command line, leaving the
+// branch to be appended.
+func mergeArgs(options types.MergeOptions) ([]string, error) {
+	args := []string{"merge", "--no-edit"}
+	switch options.FastForward {
+	case types.FastForwardAllow:
+	case types.FastForwardOnly:
+		args = append(args, "--ff-only")
+	case types.FastForwardNever:
+		if options.Squash {
+			return nil, errors.New("a squash merge cannot be combined with a merge commit")
+		}
+		args = append(args, "--no-ff")
+	default:
+		return nil, fmt.Errorf("unknown fast-forward mode %q", options.FastForward)
+	}
+
+	if options.Squash {
+		args = append(args, "--squash")
+	}
+
+	switch options.StrategyOption {
+	case types.StrategyDefault:
+	case types.StrategyOurs, types.StrategyTheirs:
+		args = append(args, "-X", string(options.StrategyOption))
+	default:
+		return nil, fmt.Errorf("unknown strategy option %q", options.StrategyOption)
+	}
+
+	if options.Message != "" {
+		args = append(args, "-m", options.Message)
+	}
+	return args, nil
+}
+
+// mergeShortcut reports MergeUpToDate when target is already part of head
+// and MergeFastForward when head is part of target. It returns an empty
+// outcome when a real merge is needed.
+func (a *App) mergeShortcut(head, target string) (types.MergeOutcome, error) {
+	merged, err := a.isAncestor(target, head)
+	if err != nil {
+		return "", err
+	}
+	if merged {
+		return types.MergeUpToDate, nil
+	}
+
+	behind, err := a.isAncestor(head, target)
+	if err != nil {
+		return "", err
+	}
+	if behind {
+		return types.MergeFastForward, nil
+	}
+	return "", nil
+}
+
+// isAncestor reports whether commit ancestor is reachable from commit.
+func (a *App) isAncestor(ancestor, commit string) (bool, error) {
+	_, err := a.executor.Execute("merge-base", "--is-ancestor", ancestor, commit)
+	var gitErr *git.GitError
+	if errors.As(err, &gitErr) && gitErr.ExitCode == 1 {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to compare %s and %s: %w", ancestor, commit, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,152 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"git-gui/backend/git"
+	"git-gui/backend/rebase"
+	"git-gui/backend/types"
+)
+
+// operationAction is a step that can be applied to an operation in progress.
+type operationAction string
+
+const (
+	actionContinue operationAction = "continue"
+	actionAbort    operationAction = "abort"
+	actionSkip     operationAction = "skip"
+)
+
+// noEditorEnv disables editors so that commits made while continuing keep
+// their prepared messages instead of waiting on a terminal. GIT_EDITOR is
+// used because it overrides both core.editor and an inherited VISUAL.
+var noEditorEnv = []string{"GIT_EDITOR=true"}
+
+// GetOperationState reports the merge, rebase, cherry-pick, revert or bisect
+// currently in progress along with any unresolved conflicts.
+func (a *App) GetOperationState() (*types.OperationState, error) {
+	head, err := a.GetHeadState()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &types.OperationState{
+		Operation:  head.Operation,
+		Step:       head.Step,
+		TotalSteps: head.TotalSteps,
+		Conflicts:  []string{},
+	}
+	if head.Operation == types.OperationNone {
+		return state, nil
+	}
+
+	if head.Operation == types.OperationRebase {
+		state.Branch = head.Branch
+	}
+	state.CanSkip = head.Operation != types.OperationMerge
+
+	output, err := a.executor.Execute("diff", "--name-only", "-z", "--diff-filter=U")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicts: %w", err)
+	}
+	state.Conflicts = git.ParsePathList(output)
+
+	return state, nil
+}
+
+// ContinueOperation resumes the operation in progress after conflicts have
+// been resolved and staged.
+func (a *App) ContinueOperation() (*types.OperationState, error) {
+	return a.applyOperationAction(actionContinue)
+}
+
+// AbortOperation cancels the operation in progress and restores the
+// repository to the state before it started.
+func (a *App) AbortOperation() (*types.OperationState, error) {
+	return a.applyOperationAction(actionAbort)
+}
+
+// SkipOperation drops the current step of the operation in progress.
+func (a *App) SkipOperation() (*types.OperationState, error) {
+	return a.applyOperationAction(actionSkip)
+}
+
+// applyOperationAction runs the git command for action against the current
+// operation and returns the resulting state.
+func (a *App) applyOperationAction(action operationAction) (*types.OperationState, error) {
+	state, err := a.GetOperationState()
+	if err != nil {
+		return nil, err
+	}
+	if state.Operation == types.OperationNone {
+		return nil, errors.New("no operation in progress")
+	}
+
+	args, err := operationArgs(state.Operation, action)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Operation == types.OperationRebase {
+		return a.applyRebaseAction(action, args)
+	}
+
+	if _, err := a.executor.ExecuteWithEnv(noEditorEnv, args...); err != nil {
+		return nil, fmt.Errorf("failed to %s %s: %w", action, state.Operation, err)
+	}
+
+	return a.GetOperationState()
+}
+
+// applyRebaseAction applies action to a rebase in progress. A rebase started
+// by ExecuteRebase keeps using the app as its editor, so that rewords and
+// squashes after the current step still get their planned messages, and its
+// plan is removed once the rebase is over.
+func (a *App) applyRebaseAction(action operationAction, args []string) (*types.OperationState, error) {
+	gitDir, err := a.gitDir()
+	if err != nil {
+		return nil, err
+	}
+
+	planPath := filepath.Join(gitDir, rebase.PlanFile)
+	if _, err := os.Stat(planPath); err == nil {
+		env, err := a.rebaseEditorEnv(gitDir)
+		if err != nil {
+			return nil, err
+		}
+		_, err = a.executor.ExecuteWithEnv(env, "rebase", "--"+string(action))
+		if err != nil {
+			return nil, fmt.Errorf("failed to %s rebase: %w", action, err)
+		}
+	} else if _, err := a.executor.ExecuteWithEnv(noEditorEnv, args...); err != nil {
+		return nil, fmt.Errorf("failed to %s rebase: %w", action, err)
+	}
+
+	state, err := a.GetOperationState()
+	if err == nil && state.Operation != types.OperationRebase {
+		os.Remove(planPath)
+	}
+	return state, err
+}
+
+// operationArgs returns the git arguments that apply action to op.
+func operationArgs(op types.RepoOperation, action operationAction) ([]string, error) {
+	if op == types.OperationBisect {
+		switch action {
+		case actionAbort:
+			return []string{"bisect", "reset"}, nil
+		case actionSkip:
+			return []string{"bisect", "skip"}, nil
+		}
+		return nil, errors.New("bisect cannot be continued; mark the commit good or bad instead")
+	}
+
+	if op == types.OperationMerge && action == actionSkip {
+		return nil, errors.New("a merge cannot be skipped")
+	}
+
+	return []string{string(op), "--" + string(action)}, nil
+}
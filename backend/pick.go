@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// errPickStopped reports that a cherry-pick or revert paused before
+// applying every commit.
+var errPickStopped = errors.New("stopped before applying every commit")
+
+// CherryPick applies the changes introduced by each of shas, in order, on
+// top of HEAD.
+func (a *App) CherryPick(shas []string, options types.CherryPickOptions) (*types.PickResult, error) {
+	args := []string{"cherry-pick"}
+	if options.RecordOrigin {
+		args = append(args, "-x")
+	}
+	args = append(args, pickArgs(options.Mainline, options.NoCommit)...)
+
+	return a.applyCommits(types.OperationCherryPick, args, shas, options.Mainline)
+}
+
+// Revert creates commits that undo the changes introduced by each of shas,
+// in order.
+func (a *App) Revert(shas []string, options types.RevertOptions) (*types.PickResult, error) {
+	args := append([]string{"revert", "--no-edit"}, pickArgs(options.Mainline, options.NoCommit)...)
+
+	return a.applyCommits(types.OperationRevert, args, shas, options.Mainline)
+}
+
+// pickArgs returns the flags shared by cherry-pick and revert.
+func pickArgs(mainline int, noCommit bool) []string {
+	var args []string
+	if mainline > 0 {
+		args = append(args, "-m", strconv.Itoa(mainline))
+	}
+	if noCommit {
+		args = append(args, "--no-commit")
+	}
+	return args
+}
+
+// applyCommits runs a cherry-pick or revert of shas with args. A run that
+// stops on a conflict is reported through the result, with op left in
+// progress for ContinueOperation, SkipOperation or AbortOperation.
+func (a *App) applyCommits(op types.RepoOperation, args, shas []string, mainline int) (*types.PickResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if len(shas) == 0 {
+		return nil, fmt.Errorf("no commits to %s", op)
+	}
+	if mainline < 0 {
+		return nil, fmt.Errorf("invalid mainline parent %d", mainline)
+	}
+	for _, sha := range shas {
+		if err := checkRevision(sha); err != nil {
+			return nil, err
+		}
+	}
+
+	head, err := a.resolveRef("HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	var conflicts []string
+	err = a.record(string(op), []string{"HEAD"}, func() (*worktreeChange, error) {
+		_, err := a.executor.Execute(append(args, shas...)...)
+		state, stateErr := a.GetHeadState()
+		if stateErr == nil && state.Operation == op {
+			return nil, errPickStopped
+		}
+		if err != nil {
+			// Runs without commits stop on conflicts without CHERRY_PICK_HEAD
+			// or REVERT_HEAD, so look at the index instead.
+			output, listErr := a.executor.Execute("diff", "--name-only", "-z", "--diff-filter=U")
+			if conflicts = git.ParsePathList(output); listErr == nil && len(conflicts) > 0 {
+				return nil, errPickStopped
+			}
+			return nil, fmt.Errorf("failed to %s %s: %w", op, strings.Join(shas, " "), err)
+		}
+		return a.revisionChange(string(op), head)
+	})
+	if errors.Is(err, errPickStopped) && len(conflicts) > 0 {
+		result := &types.PickResult{Operation: op, Conflicts: conflicts}
+		if result.HeadSHA, err = a.resolveRef("HEAD"); err != nil {
+			return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+		}
+		return result, nil
+	}
+	if errors.Is(err, errPickStopped) {
+		return a.pickStop(op)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.PickResult{Operation: op, Completed: true, Conflicts: []string{}}
+	if result.HeadSHA, err = a.resolveRef("HEAD"); err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return result, nil
+}
+
+// pickStop describes the commit a cherry-pick or revert stopped at.
+func (a *App) pickStop(op types.RepoOperation) (*types.PickResult, error) {
+	state, err := a.GetOperationState()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.PickResult{Operation: op, Conflicts: state.Conflicts}
+	stopRef := "CHERRY_PICK_HEAD"
+	if op == types.OperationRevert {
+		stopRef = "REVERT_HEAD"
+	}
+	if result.StoppedAt, err = a.resolveRef(stopRef); err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", stopRef, err)
+	}
+	if result.HeadSHA, err = a.resolveRef("HEAD"); err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return result, nil
+}
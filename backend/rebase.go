@@ -0,0 +1,207 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"git-gui/backend/git"
+	"git-gui/backend/rebase"
+	"git-gui/backend/types"
+)
+
+// errRebaseStopped reports that a rebase paused before finishing.
+var errRebaseStopped = errors.New("rebase stopped")
+
+// commitSHAPattern matches a full or abbreviated commit SHA.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{4,64}$`)
+
+// PlanRebase returns the todo list for rebasing the current branch onto
+// onto: every non-merge commit since onto, oldest first, as a pick step.
+func (a *App) PlanRebase(onto string) (*types.RebasePlan, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	head, ontoSHA, err := a.headAndRevision(onto)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := a.executor.Execute("log", "--reverse", "--no-merges", git.CommitSummaryFormat, ontoSHA+".."+head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits to rebase: %w", err)
+	}
+
+	plan := &types.RebasePlan{Onto: onto, OntoSHA: ontoSHA, HeadSHA: head, Steps: []types.RebaseStep{}}
+	for _, commit := range git.ParseCommitSummaries(output) {
+		plan.Steps = append(plan.Steps, types.RebaseStep{
+			Action:  types.RebasePick,
+			SHA:     commit.SHA,
+			Subject: commit.Subject,
+		})
+	}
+	return plan, nil
+}
+
+// ExecuteRebase runs an interactive rebase with the steps of plan, which may
+// have been reordered, dropped or given other actions since PlanRebase.
+// The app binary stands in for git's editors, writing the todo list and
+// any new commit messages. A rebase that stops for a conflict, an edit or
+// a failing exec step is reported as such and stays in progress.
+func (a *App) ExecuteRebase(plan types.RebasePlan) (*types.RebaseResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if err := checkRebaseSteps(plan.Steps); err != nil {
+		return nil, err
+	}
+	if !commitSHAPattern.MatchString(plan.OntoSHA) {
+		return nil, fmt.Errorf("invalid onto commit %q", plan.OntoSHA)
+	}
+
+	head, err := a.resolveRef("HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	if head != plan.HeadSHA {
+		return nil, errors.New("HEAD has moved since the rebase was planned")
+	}
+
+	gitDir, err := a.gitDir()
+	if err != nil {
+		return nil, err
+	}
+	planPath := filepath.Join(gitDir, rebase.PlanFile)
+	err = rebase.WritePlan(planPath, rebase.Plan{
+		Todo:     git.FormatRebaseTodo(plan.Steps),
+		Messages: git.RebaseMessages(plan.Steps),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write rebase plan: %w", err)
+	}
+	env, err := a.rebaseEditorEnv(gitDir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.record("rebase", []string{"HEAD"}, func() (*worktreeChange, error) {
+		// git exits with success when it stops at an edit step
+		_, err := a.executor.ExecuteWithEnv(env, "rebase", "-i", plan.OntoSHA)
+		head, headErr := a.GetHeadState()
+		if headErr == nil && head.Operation == types.OperationRebase {
+			return nil, errRebaseStopped
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebase onto %s: %w", plan.Onto, err)
+		}
+		return a.revisionChange("rebase onto "+plan.Onto, plan.HeadSHA)
+	})
+	if errors.Is(err, errRebaseStopped) {
+		return a.rebaseStop(gitDir)
+	}
+	os.Remove(planPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RebaseResult{Completed: true, Conflicts: []string{}}, nil
+}
+
+// rebaseEditorEnv returns the environment that makes git use the app binary
+// as its editors for the rebase plan in gitDir.
+func (a *App) rebaseEditorEnv(gitDir string) ([]string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate the editor helper: %w", err)
+	}
+	return rebase.EditorEnv(executable, filepath.Join(gitDir, rebase.PlanFile)), nil
+}
+
+// rebaseStop describes the step a rebase in progress stopped at.
+func (a *App) rebaseStop(gitDir string) (*types.RebaseResult, error) {
+	state, err := a.GetOperationState()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.RebaseResult{
+		Step:       state.Step,
+		TotalSteps: state.TotalSteps,
+		Conflicts:  state.Conflicts,
+	}
+
+	action, arg := git.ParseTodoLine(rebase.CurrentStep(gitDir))
+	result.StoppedAt = arg
+	switch {
+	case len(state.Conflicts) > 0:
+		result.Stop = types.RebaseStopConflict
+	case action == types.RebaseExec:
+		result.Stop = types.RebaseStopExec
+	case action == types.RebaseEdit:
+		result.Stop = types.RebaseStopEdit
+	default:
+		result.Stop = types.RebaseStopOther
+	}
+	return result, nil
+}
+
+// revisionChange snapshots, as they were at rev, the files whose staged
+// content differs from rev, for operations such as rebase and cherry-pick
+// that only rewrite files without local changes.
+func (a *App) revisionChange(reason, rev string) (*worktreeChange, error) {
+	output, err := a.executor.Execute("diff", "--name-only", "-z", "--cached", rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	paths := git.ParsePathList(output)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	snapshotID, err := a.snapshotRevision(reason, rev, paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot files: %w", err)
+	}
+	return &worktreeChange{paths: paths, snapshotID: snapshotID}, nil
+}
+
+// checkRebaseSteps validates a todo list before it is handed to git.
+func checkRebaseSteps(steps []types.RebaseStep) error {
+	if len(steps) == 0 {
+		return errors.New("rebase plan has no steps")
+	}
+
+	hasCommit := false
+	for i, step := range steps {
+		switch step.Action {
+		case types.RebaseExec:
+			if strings.TrimSpace(step.Command) == "" || strings.ContainsAny(step.Command, "\r\n") {
+				return fmt.Errorf("step %d: exec needs a single-line command", i+1)
+			}
+			continue
+		case types.RebasePick, types.RebaseReword, types.RebaseEdit, types.RebaseDrop:
+		case types.RebaseSquash, types.RebaseFixup:
+			if !hasCommit {
+				return fmt.Errorf("step %d: cannot %s without a previous commit", i+1, step.Action)
+			}
+		default:
+			return fmt.Errorf("step %d: unknown action %q", i+1, step.Action)
+		}
+
+		if !commitSHAPattern.MatchString(step.SHA) {
+			return fmt.Errorf("step %d: invalid commit %q", i+1, step.SHA)
+		}
+		if strings.ContainsAny(step.Subject, "\r\n") {
+			return fmt.Errorf("step %d: subject must be a single line", i+1)
+		}
+		if step.Action != types.RebaseDrop {
+			hasCommit = true
+		}
+	}
+	return nil
+}
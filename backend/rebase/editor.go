@@ -0,0 +1,122 @@
+// Package rebase implements the editor helper that drives interactive
+// rebases. The app binary is set as git's sequence and commit message
+// editor, and when git invokes it with HelperFlag it writes the prepared
+// todo list or commit message instead of opening a terminal editor.
+package rebase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-gui/backend/git"
+)
+
+// HelperFlag is the first argument that makes the app binary act as an
+// editor for git.
+const HelperFlag = "--rebase-editor"
+
+// PlanFile is the name of the plan file inside the git directory. The
+// helper finds the rebase state next to it.
+const PlanFile = "gitgui-rebase.json"
+
+const (
+	modeSequence = "sequence"
+	modeMessage  = "message"
+)
+
+// Plan is what the helper writes on git's behalf: the todo list, and the
+// commit messages keyed by the SHA of the step that opens the editor.
+type Plan struct {
+	Todo     string            `json:"Todo"`
+	Messages map[string]string `json:"Messages"`
+}
+
+// WritePlan saves plan for the helper to read.
+func WritePlan(path string, plan Plan) error {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// EditorEnv returns the environment that makes git use executable, the
+// app binary, as the editor for the rebase planned in planPath.
+func EditorEnv(executable, planPath string) []string {
+	command := func(mode string) string {
+		return strings.Join([]string{shellQuote(executable), HelperFlag, mode, shellQuote(planPath)}, " ")
+	}
+	return []string{
+		"GIT_SEQUENCE_EDITOR=" + command(modeSequence),
+		"GIT_EDITOR=" + command(modeMessage),
+	}
+}
+
+// RunEditor handles an invocation of the helper with the arguments that
+// follow HelperFlag: the mode, the plan path, and the file git asks to be
+// edited. A missing plan or message leaves the file unchanged.
+func RunEditor(args []string) error {
+	if len(args) != 3 {
+		return errors.New("usage: " + HelperFlag + " sequence|message <plan> <file>")
+	}
+	mode, planPath, file := args[0], args[1], args[2]
+
+	data, err := os.ReadFile(planPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return fmt.Errorf("invalid rebase plan %s: %w", planPath, err)
+	}
+
+	switch mode {
+	case modeSequence:
+		return os.WriteFile(file, []byte(plan.Todo), 0o644)
+	case modeMessage:
+		message, ok := currentMessage(plan, filepath.Dir(planPath))
+		if !ok {
+			return nil
+		}
+		return os.WriteFile(file, []byte(message+"\n"), 0o644)
+	default:
+		return fmt.Errorf("unknown editor mode %q", mode)
+	}
+}
+
+// CurrentStep returns the last line of the rebase done file in gitDir,
+// which is the step git is processing or stopped at.
+func CurrentStep(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "rebase-merge", "done"))
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	return lines[len(lines)-1]
+}
+
+// currentMessage looks up the message for the step git is processing.
+func currentMessage(plan Plan, gitDir string) (string, bool) {
+	_, sha := git.ParseTodoLine(CurrentStep(gitDir))
+	if sha == "" {
+		return "", false
+	}
+	for key, message := range plan.Messages {
+		if strings.HasPrefix(key, sha) || strings.HasPrefix(sha, key) {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// shellQuote quotes s for the shell git runs editors with.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
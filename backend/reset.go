@@ -0,0 +1,192 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// recoveryRefPrefix is the ref namespace holding the state of HEAD and the
+// working tree from before each hard reset.
+const recoveryRefPrefix = "refs/gitgui/recovery/"
+
+// PreviewReset reports what resetting the current branch to revision with
+// the given mode would take away, without changing anything.
+func (a *App) PreviewReset(revision string, mode types.ResetMode) (*types.ResetPreflight, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if err := checkResetMode(mode); err != nil {
+		return nil, err
+	}
+
+	current, target, err := a.headAndRevision(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	preflight := &types.ResetPreflight{
+		Revision:       revision,
+		Mode:           mode,
+		CurrentSHA:     current,
+		TargetSHA:      target,
+		DiscardedFiles: []string{},
+	}
+
+	output, err := a.executor.Execute("log", git.CommitSummaryFormat, target+".."+current, "--not", "--remotes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unpushed commits: %w", err)
+	}
+	preflight.UnpushedCommits = git.ParseCommitSummaries(output)
+
+	if mode == types.ResetHard {
+		output, err := a.executor.Execute("diff", "--name-only", "-z", "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list uncommitted changes: %w", err)
+		}
+		preflight.DiscardedFiles = git.ParsePathList(output)
+	}
+
+	return preflight, nil
+}
+
+// ResetTo moves the current branch to revision. Soft keeps the index and
+// working tree, mixed resets the index, hard resets both, and keep resets
+// both but refuses to overwrite uncommitted changes. Before a hard reset
+// the previous state is stored under a recovery ref.
+func (a *App) ResetTo(revision string, mode types.ResetMode) (*types.ResetResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if err := checkResetMode(mode); err != nil {
+		return nil, err
+	}
+
+	current, target, err := a.headAndRevision(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.ResetResult{Mode: mode, PreviousSHA: current, TargetSHA: target}
+	err = a.record("reset", []string{"HEAD"}, func() (*worktreeChange, error) {
+		change, err := a.resetWorktreeChange(mode, target)
+		if err != nil {
+			return nil, err
+		}
+
+		if mode == types.ResetHard {
+			if result.RecoveryRef, err = a.storeRecoveryRef(current); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := a.executor.Execute("reset", "--"+string(mode), target); err != nil {
+			return nil, fmt.Errorf("failed to reset to %s: %w", revision, err)
+		}
+		return change, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// headAndRevision resolves HEAD and revision to commit SHAs.
+func (a *App) headAndRevision(revision string) (string, string, error) {
+	if err := checkRevision(revision); err != nil {
+		return "", "", err
+	}
+
+	current, err := a.resolveRef("HEAD")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	if current == "" {
+		return "", "", errors.New("the current branch has no commits yet")
+	}
+
+	target, err := a.resolveCommit(revision)
+	if err != nil {
+		return "", "", err
+	}
+
+	return current, target, nil
+}
+
+// resolveCommit returns the SHA of the commit revision names.
+func (a *App) resolveCommit(revision string) (string, error) {
+	sha, err := a.resolveRef(revision + "^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", revision, err)
+	}
+	if sha == "" {
+		return "", fmt.Errorf("unknown revision %q", revision)
+	}
+	return sha, nil
+}
+
+// resetWorktreeChange snapshots the working tree files a hard or keep reset
+// to target will overwrite, so the reset can be undone.
+func (a *App) resetWorktreeChange(mode types.ResetMode, target string) (*worktreeChange, error) {
+	var args []string
+	switch mode {
+	case types.ResetHard:
+		args = []string{"diff", "--name-only", "-z", target}
+	case types.ResetKeep:
+		args = []string{"diff", "--name-only", "-z", "HEAD", target}
+	default:
+		return nil, nil
+	}
+
+	output, err := a.executor.Execute(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed by the reset: %w", err)
+	}
+
+	paths := git.ParsePathList(output)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	snapshotID, err := a.snapshotFiles("reset --"+string(mode)+" "+target, paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot files: %w", err)
+	}
+	return &worktreeChange{paths: paths, snapshotID: snapshotID}, nil
+}
+
+// storeRecoveryRef records HEAD, and any uncommitted changes as a stash
+// commit on top of it, under recoveryRefPrefix and returns the ref.
+func (a *App) storeRecoveryRef(head string) (string, error) {
+	output, err := a.executor.Execute("stash", "create", "reset recovery")
+	if err != nil {
+		return "", fmt.Errorf("failed to save uncommitted changes: %w", err)
+	}
+
+	sha := strings.TrimSpace(output)
+	if sha == "" {
+		sha = head
+	}
+
+	ref := recoveryRefPrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
+	if _, err := a.executor.Execute("update-ref", ref, sha); err != nil {
+		return "", fmt.Errorf("failed to store recovery ref: %w", err)
+	}
+	return ref, nil
+}
+
+// checkResetMode rejects unknown reset modes.
+func checkResetMode(mode types.ResetMode) error {
+	switch mode {
+	case types.ResetSoft, types.ResetMixed, types.ResetHard, types.ResetKeep:
+		return nil
+	default:
+		return fmt.Errorf("unknown reset mode %q", mode)
+	}
+}
@@ -0,0 +1,130 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// defaultSearchLimit caps the results of a search that sets no limit.
+const defaultSearchLimit = 200
+
+// Search finds commits by message, author or changes to the number of
+// occurrences of a string (pickaxe), or lines of content with git grep.
+func (a *App) Search(query types.SearchQuery) (*types.SearchResult, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+	if query.Pattern == "" {
+		return nil, errors.New("search pattern required")
+	}
+	if query.Context < 0 || query.Limit < 0 {
+		return nil, fmt.Errorf("invalid search window context=%d limit=%d", query.Context, query.Limit)
+	}
+	if query.Revision != "" {
+		if err := checkRevision(query.Revision); err != nil {
+			return nil, err
+		}
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+
+	switch query.Mode {
+	case types.SearchMessage, types.SearchAuthor, types.SearchPickaxe, types.SearchPickaxeRegex:
+		return a.searchCommits(query)
+	case types.SearchContent:
+		return a.searchContent(query)
+	}
+	return nil, fmt.Errorf("unknown search mode %q", query.Mode)
+}
+
+// searchCommits runs a git log search. One commit more than the limit is
+// requested to tell whether the results were cut short.
+func (a *App) searchCommits(query types.SearchQuery) (*types.SearchResult, error) {
+	args := []string{"log", git.SearchCommitFormat, "-z", "-n" + strconv.Itoa(query.Limit+1)}
+	switch query.Mode {
+	case types.SearchMessage:
+		args = append(args, "--grep="+query.Pattern)
+	case types.SearchAuthor:
+		args = append(args, "--author="+query.Pattern)
+	case types.SearchPickaxe:
+		args = append(args, "--name-only", "-S"+query.Pattern)
+	case types.SearchPickaxeRegex:
+		args = append(args, "--name-only", "-G"+query.Pattern)
+	}
+	if query.IgnoreCase {
+		args = append(args, "-i")
+	}
+	if query.Fixed && (query.Mode == types.SearchMessage || query.Mode == types.SearchAuthor) {
+		args = append(args, "-F")
+	}
+
+	revision := query.Revision
+	if revision == "" {
+		revision = "HEAD"
+	}
+	args = append(append(args, revision, "--"), query.Paths...)
+
+	output, err := a.executor.Execute(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search commits: %w", err)
+	}
+
+	result := &types.SearchResult{
+		Mode:    query.Mode,
+		Commits: git.ParseSearchCommits(output),
+		Matches: []types.ContentMatch{},
+	}
+	if len(result.Commits) > query.Limit {
+		result.Commits = result.Commits[:query.Limit]
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+// searchContent runs git grep over the working tree or query.Revision. git
+// grep exits with status 1 when nothing matches.
+func (a *App) searchContent(query types.SearchQuery) (*types.SearchResult, error) {
+	args := []string{"grep", "-n", "-z", "--column", "--full-name"}
+	if query.IgnoreCase {
+		args = append(args, "-i")
+	}
+	if query.Fixed {
+		args = append(args, "-F")
+	}
+	if query.Context > 0 {
+		args = append(args, "-C"+strconv.Itoa(query.Context))
+	}
+	args = append(args, "-e", query.Pattern)
+
+	prefix := ""
+	if query.Revision != "" {
+		args = append(args, query.Revision)
+		prefix = query.Revision + ":"
+	}
+	args = append(append(args, "--"), query.Paths...)
+
+	output, err := a.executor.Execute(args...)
+	var gitErr *git.GitError
+	if errors.As(err, &gitErr) && gitErr.ExitCode == 1 {
+		output, err = "", nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search content: %w", err)
+	}
+
+	result := &types.SearchResult{
+		Mode:    query.Mode,
+		Commits: []types.SearchCommit{},
+		Matches: git.ParseGrep(output, prefix, query.Context),
+	}
+	if len(result.Matches) > query.Limit {
+		result.Matches = result.Matches[:query.Limit]
+		result.Truncated = true
+	}
+	return result, nil
+}
@@ -0,0 +1,247 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// snapshotRefPrefix is the ref namespace that keeps snapshot commits
+// reachable, and so safe from garbage collection, until they are restored.
+const snapshotRefPrefix = "refs/gitgui/snapshots/"
+
+// snapshotPathsEntry names the blob in a snapshot tree that lists the
+// original paths, NUL-separated, in the order of the numbered blobs.
+const snapshotPathsEntry = "paths"
+
+// ListSnapshots returns the saved snapshots, newest first.
+func (a *App) ListSnapshots() ([]types.Snapshot, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	output, err := a.executor.Execute("for-each-ref", "--sort=-refname",
+		"--format=%(refname:lstrip=3)%00%(creatordate:unix)%00%(contents:subject)%00%(contents:body)%1e",
+		snapshotRefPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	return git.ParseSnapshots(output), nil
+}
+
+// RestoreSnapshot writes the files saved in a snapshot back into the working
+// tree and then deletes the snapshot.
+func (a *App) RestoreSnapshot(id string) error {
+	if a.executor == nil {
+		return errors.New("no repository initialized")
+	}
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return fmt.Errorf("invalid snapshot id %q", id)
+	}
+
+	if _, err := a.restoreSnapshot(id); err != nil {
+		return err
+	}
+
+	ref := snapshotRefPrefix + id
+	if _, err := a.executor.Execute("update-ref", "-d", ref); err != nil {
+		return fmt.Errorf("failed to delete snapshot %s: %w", id, err)
+	}
+	return nil
+}
+
+// restoreSnapshot writes the files saved in a snapshot back into the working
+// tree and returns their paths.
+func (a *App) restoreSnapshot(id string) ([]string, error) {
+	ref := snapshotRefPrefix + id
+	tree, err := a.executor.Execute("ls-tree", "-z", ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", id, err)
+	}
+
+	entries := git.ParseTreeEntries(tree)
+	var paths []string
+	for _, entry := range entries {
+		if entry.Name != snapshotPathsEntry {
+			continue
+		}
+		list, err := a.executor.Execute("cat-file", "blob", entry.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot %s: %w", id, err)
+		}
+		paths = git.ParsePathList(list)
+	}
+
+	var restored []string
+	for _, entry := range entries {
+		if entry.Name == snapshotPathsEntry {
+			continue
+		}
+		idx, err := strconv.Atoi(entry.Name)
+		if err != nil || idx >= len(paths) {
+			return nil, fmt.Errorf("snapshot %s is malformed", id)
+		}
+		if err := a.restoreBlob(paths[idx], entry); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", paths[idx], err)
+		}
+		restored = append(restored, paths[idx])
+	}
+
+	return restored, nil
+}
+
+// snapshotFiles saves the working tree content of paths as a commit under
+// snapshotRefPrefix and returns its id. Each blob is stored under its index
+// in the tree and the snapshotPathsEntry blob lists the original paths, so
+// nested paths need no intermediate trees. Missing files are skipped; an empty id
+// means there was nothing to save.
+func (a *App) snapshotFiles(reason string, paths []string) (string, error) {
+	var tree strings.Builder
+	var saved []string
+
+	for _, path := range paths {
+		fullPath, err := a.worktreePath(path)
+		if err != nil {
+			return "", err
+		}
+		info, err := os.Lstat(fullPath)
+		if errors.Is(err, os.ErrNotExist) || (err == nil && info.IsDir()) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		mode := "100644"
+		var content []byte
+		if info.Mode()&os.ModeSymlink != 0 {
+			mode = "120000"
+			target, err := os.Readlink(fullPath)
+			if err != nil {
+				return "", err
+			}
+			content = []byte(target)
+		} else {
+			if info.Mode()&0o111 != 0 {
+				mode = "100755"
+			}
+			if content, err = os.ReadFile(fullPath); err != nil {
+				return "", err
+			}
+		}
+
+		blob, err := a.executor.ExecuteWithInput(string(content), "hash-object", "-w", "--stdin")
+		if err != nil {
+			return "", fmt.Errorf("failed to save %s: %w", path, err)
+		}
+		fmt.Fprintf(&tree, "%s blob %s\t%d\n", mode, strings.TrimSpace(blob), len(saved))
+		saved = append(saved, path)
+	}
+
+	return a.saveSnapshot(reason, tree.String(), saved)
+}
+
+// saveSnapshot creates the snapshot commit for a tree listing the blobs of
+// paths by index, and the ref that keeps it. The commit message repeats the
+// paths one per line for ListSnapshots.
+func (a *App) saveSnapshot(reason, tree string, paths []string) (string, error) {
+	if len(paths) == 0 {
+		return "", nil
+	}
+
+	list, err := a.executor.ExecuteWithInput(strings.Join(paths, "\x00")+"\x00", "hash-object", "-w", "--stdin")
+	if err != nil {
+		return "", fmt.Errorf("failed to save snapshot paths: %w", err)
+	}
+	tree += fmt.Sprintf("100644 blob %s\t%s\n", strings.TrimSpace(list), snapshotPathsEntry)
+
+	treeSHA, err := a.executor.ExecuteWithInput(tree, "mktree")
+	if err != nil {
+		return "", fmt.Errorf("failed to create snapshot tree: %w", err)
+	}
+
+	message := reason + "\n\n" + strings.Join(paths, "\n")
+	commit, err := a.executor.Execute("-c", "user.name=git-gui", "-c", "user.email=git-gui@localhost",
+		"commit-tree", strings.TrimSpace(treeSHA), "-m", message)
+	if err != nil {
+		return "", fmt.Errorf("failed to create snapshot commit: %w", err)
+	}
+
+	id := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if _, err := a.executor.Execute("update-ref", snapshotRefPrefix+id, strings.TrimSpace(commit)); err != nil {
+		return "", fmt.Errorf("failed to record snapshot: %w", err)
+	}
+
+	return id, nil
+}
+
+// snapshotRevision saves the content of paths at rev as a snapshot, in the
+// same layout as snapshotFiles. Paths missing at rev are skipped.
+func (a *App) snapshotRevision(reason, rev string, paths []string) (string, error) {
+	output, err := a.executor.Execute(append([]string{"ls-tree", "-z", rev, "--"}, paths...)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to list files at %s: %w", rev, err)
+	}
+
+	var tree strings.Builder
+	var saved []string
+	for _, entry := range git.ParseTreeEntries(output) {
+		if entry.Type != "blob" {
+			continue
+		}
+		fmt.Fprintf(&tree, "%s blob %s\t%d\n", entry.Mode, entry.SHA, len(saved))
+		saved = append(saved, entry.Name)
+	}
+
+	return a.saveSnapshot(reason, tree.String(), saved)
+}
+
+// restoreBlob writes a snapshotted blob back to path with its saved mode.
+func (a *App) restoreBlob(path string, entry git.TreeEntry) error {
+	fullPath, err := a.worktreePath(path)
+	if err != nil {
+		return err
+	}
+
+	content, err := a.executor.Execute("cat-file", "blob", entry.SHA)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return err
+	}
+
+	// Replace whatever is in the way unless it is a regular file that can be
+	// written in place; writing through a symlink would change its target.
+	info, err := os.Lstat(fullPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	case entry.Mode == "120000" || !info.Mode().IsRegular():
+		if err := os.Remove(fullPath); err != nil {
+			return err
+		}
+	}
+
+	if entry.Mode == "120000" {
+		return os.Symlink(content, fullPath)
+	}
+
+	perm := os.FileMode(0o644)
+	if entry.Mode == "100755" {
+		perm = 0o755
+	}
+	if err := os.WriteFile(fullPath, []byte(content), perm); err != nil {
+		return err
+	}
+	return os.Chmod(fullPath, perm)
+}
@@ -0,0 +1,90 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StageFiles adds the current working tree content of paths to the index.
+// Deleted paths are staged as deletions.
+func (a *App) StageFiles(paths []string) error {
+	if a.executor == nil {
+		return errors.New("no repository initialized")
+	}
+	if len(paths) == 0 {
+		return errors.New("no files to stage")
+	}
+
+	return a.journaled("stage", nil, func() error {
+		if _, err := a.executor.Execute(append([]string{"add", "--"}, paths...)...); err != nil {
+			return fmt.Errorf("failed to stage files: %w", err)
+		}
+		return nil
+	})
+}
+
+// StageAll stages every change in the working tree, including untracked
+// and deleted files.
+func (a *App) StageAll() error {
+	if a.executor == nil {
+		return errors.New("no repository initialized")
+	}
+
+	return a.journaled("stage all", nil, func() error {
+		if _, err := a.executor.Execute("add", "--all"); err != nil {
+			return fmt.Errorf("failed to stage files: %w", err)
+		}
+		return nil
+	})
+}
+
+// UnstageFiles resets the index entries of paths to HEAD, leaving the
+// working tree untouched.
+func (a *App) UnstageFiles(paths []string) error {
+	if a.executor == nil {
+		return errors.New("no repository initialized")
+	}
+	if len(paths) == 0 {
+		return errors.New("no files to unstage")
+	}
+
+	return a.journaled("unstage", nil, func() error {
+		return a.unstage(paths)
+	})
+}
+
+// UnstageAll resets the whole index to HEAD, leaving the working tree
+// untouched.
+func (a *App) UnstageAll() error {
+	if a.executor == nil {
+		return errors.New("no repository initialized")
+	}
+
+	return a.journaled("unstage all", nil, func() error {
+		return a.unstage([]string{"."})
+	})
+}
+
+// unstage resets the index entries matching pathspecs. On an unborn branch
+// there is no HEAD to restore from, so the entries are removed instead.
+func (a *App) unstage(pathspecs []string) error {
+	unborn, err := a.isUnborn()
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	args := []string{"restore", "--staged", "--"}
+	if unborn {
+		args = []string{"rm", "--cached", "-r", "-q", "--ignore-unmatch", "--"}
+	}
+	if _, err := a.executor.Execute(append(args, pathspecs...)...); err != nil {
+		return fmt.Errorf("failed to unstage files: %w", err)
+	}
+	return nil
+}
+
+// isUnborn reports whether HEAD points to a branch without any commits.
+func (a *App) isUnborn() (bool, error) {
+	sha, err := a.resolveRef("HEAD")
+	return sha == "", err
+}
@@ -0,0 +1,127 @@
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// GetChangeStats counts the lines added and removed per file in the staged
+// and in the unstaged changes. Untracked files are unstaged additions.
+func (a *App) GetChangeStats() (*types.ChangeStats, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	staged, err := a.numstat("diff", "--numstat", "-z", "-M", "--cached")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged change stats: %w", err)
+	}
+
+	unstaged, err := a.numstat("diff", "--numstat", "-z", "-M")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unstaged change stats: %w", err)
+	}
+
+	untracked, err := a.untrackedStats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get untracked change stats: %w", err)
+	}
+	for _, file := range untracked {
+		unstaged.Files = append(unstaged.Files, file)
+		unstaged.Insertions += file.Insertions
+	}
+	unstaged.FilesChanged = len(unstaged.Files)
+
+	return &types.ChangeStats{Staged: staged, Unstaged: unstaged}, nil
+}
+
+// untrackedStats counts the lines of untracked, non-ignored files, which git
+// diff does not see. The files are read from the working tree rather than
+// diffed one by one.
+func (a *App) untrackedStats() ([]types.FileStat, error) {
+	output, err := a.executor.Execute("ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	files := []types.FileStat{}
+	for _, path := range git.ParsePathList(output) {
+		fullPath, err := a.worktreePath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		file := types.FileStat{Path: path}
+		info, err := os.Lstat(fullPath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			continue
+		case err != nil:
+			return nil, err
+		case info.Mode()&os.ModeSymlink != 0:
+			// git diffs a symlink as a one-line file holding its target.
+			file.Insertions = 1
+		case info.Mode().IsRegular():
+			if file.Insertions, file.Binary, err = countLines(fullPath); err != nil {
+				return nil, err
+			}
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// binaryCheckBytes is how much of a file git looks at for a NUL byte when
+// deciding whether the file is binary.
+const binaryCheckBytes = 8000
+
+// countLines returns the number of lines in the file at path, counting a
+// final line without a newline, or reports that the file is binary.
+func countLines(path string) (int, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 64*1024)
+	lines, read := 0, 0
+	last := byte('\n')
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if read < binaryCheckBytes && bytes.IndexByte(buf[:min(n, binaryCheckBytes-read)], 0) != -1 {
+				return 0, true, nil
+			}
+			read += n
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	if last != '\n' {
+		lines++
+	}
+	return lines, false, nil
+}
+
+// numstat runs a git command that prints --numstat -z output and parses it.
+func (a *App) numstat(args ...string) (types.DiffStat, error) {
+	output, err := a.executor.Execute(args...)
+	if err != nil {
+		return types.DiffStat{}, err
+	}
+	return git.ParseNumstat(output), nil
+}
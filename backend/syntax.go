@@ -0,0 +1,101 @@
+package backend
+
+import (
+	"fmt"
+	"strings"
+
+	"git-gui/backend/git"
+	"git-gui/backend/highlight"
+	"git-gui/backend/types"
+)
+
+// GetDiffHighlight returns syntax tokens for each line of the diff GetDiff
+// produces for the same file and target. Both versions of the file are
+// highlighted in full so that multi-line constructs are tokenized correctly.
+// A truncated diff has no hunks, so no tokens are returned for it; use
+// GetDiffHunksHighlight for the hunks loaded with GetDiffHunks instead.
+func (a *App) GetDiffHighlight(filePath string, target types.DiffTarget) (*types.DiffHighlight, error) {
+	result, err := a.GetDiff(filePath, target)
+	if err != nil {
+		return nil, err
+	}
+	return a.highlightDiff(filePath, target, result)
+}
+
+// GetDiffHunksHighlight returns syntax tokens for the hunks GetDiffHunks
+// returns for the same file, target and window.
+func (a *App) GetDiffHunksHighlight(filePath string, target types.DiffTarget, offset, limit int) (*types.DiffHighlight, error) {
+	if offset < 0 || limit < 1 {
+		return nil, fmt.Errorf("invalid hunk window offset=%d limit=%d", offset, limit)
+	}
+
+	output, err := a.rawDiff(filePath, target)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := git.ParseDiff(filePath, git.DiffHeader(output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff for %s: %w", filePath, err)
+	}
+	result.Hunks, _ = git.ParseDiffHunks(output, offset, limit)
+	return a.highlightDiff(filePath, target, result)
+}
+
+// highlightDiff maps the tokens of both versions of filePath onto the hunks
+// of result, a diff between the sides of target.
+func (a *App) highlightDiff(filePath string, target types.DiffTarget, result *types.DiffResult) (*types.DiffHighlight, error) {
+	language := highlight.DetectLanguage(filePath, a.linguistLanguage(filePath))
+	if language == "" || result.IsBinary || len(result.Hunks) == 0 {
+		return highlight.HighlightDiff(result, language, "", "")
+	}
+
+	oldRev, newRev := diffSides(target)
+	var oldSource, newSource string
+	if !result.IsNew {
+		oldSource, _ = a.readFileAt(filePath, oldRev)
+	}
+	if !result.IsDeleted {
+		newSource, _ = a.readFileAt(filePath, newRev)
+	}
+
+	highlighted, err := highlight.HighlightDiff(result, language, oldSource, newSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to highlight %s: %w", filePath, err)
+	}
+	return highlighted, nil
+}
+
+// linguistLanguage returns the linguist-language attribute set for path in
+// .gitattributes, or an empty string when it is unset.
+func (a *App) linguistLanguage(path string) string {
+	output, err := a.executor.Execute("check-attr", "linguist-language", "--", path)
+	if err != nil {
+		return ""
+	}
+
+	// Output has the form "<path>: linguist-language: <value>"
+	idx := strings.LastIndex(output, ": ")
+	if idx == -1 {
+		return ""
+	}
+	value := strings.TrimSpace(output[idx+2:])
+	if value == "unspecified" || value == "unset" || value == "set" {
+		return ""
+	}
+	return value
+}
+
+// diffSides returns the revisions, in the form readFileAt accepts, of the
+// old and new sides of target.
+func diffSides(target types.DiffTarget) (string, string) {
+	switch target.Mode {
+	case types.DiffWorktreeToIndex:
+		return indexRevision, ""
+	case types.DiffIndexToHead:
+		return "HEAD", indexRevision
+	case types.DiffWorktreeToHead:
+		return "HEAD", ""
+	}
+	return target.From, target.To
+}
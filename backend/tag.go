@@ -0,0 +1,124 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+)
+
+// tagRefPrefix is the namespace git keeps tags in.
+const tagRefPrefix = "refs/tags/"
+
+// ListTags returns all tags, newest first.
+func (a *App) ListTags() ([]types.Tag, error) {
+	if a.executor == nil {
+		return nil, errors.New("no repository initialized")
+	}
+
+	output, err := a.executor.Execute("for-each-ref", "--sort=-creatordate", git.TagFormat, tagRefPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return git.ParseTags(output), nil
+}
+
+// CreateTag tags target, or HEAD when target is empty. A message makes the
+// tag annotated, and sign makes it a GPG-signed annotated tag.
+func (a *App) CreateTag(name, target, message string, sign bool) error {
+	if err := a.checkTagName(name); err != nil {
+		return err
+	}
+	if sign && message == "" {
+		return errors.New("a signed tag requires a message")
+	}
+	if target == "" {
+		target = "HEAD"
+	}
+	if err := checkRevision(target); err != nil {
+		return err
+	}
+
+	args := []string{"tag"}
+	switch {
+	case sign:
+		args = append(args, "-s", "-m", message)
+	case message != "":
+		args = append(args, "-a", "-m", message)
+	}
+	args = append(args, name, target)
+
+	return a.journaled("create tag", []string{tagRefPrefix + name}, func() error {
+		if _, err := a.executor.Execute(args...); err != nil {
+			return fmt.Errorf("failed to create tag %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// DeleteTag deletes a local tag.
+func (a *App) DeleteTag(name string) error {
+	if err := a.checkTagName(name); err != nil {
+		return err
+	}
+
+	return a.journaled("delete tag", []string{tagRefPrefix + name}, func() error {
+		if _, err := a.executor.Execute("tag", "-d", name); err != nil {
+			return fmt.Errorf("failed to delete tag %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// PushTag pushes a single tag to remote.
+func (a *App) PushTag(remote, name string) error {
+	if err := a.checkTagRemote(remote, name); err != nil {
+		return err
+	}
+
+	if _, err := a.executor.Execute("push", remote, tagRefPrefix+name); err != nil {
+		return fmt.Errorf("failed to push tag %s to %s: %w", name, remote, err)
+	}
+	return nil
+}
+
+// DeleteRemoteTag deletes a tag from remote, leaving any local tag alone.
+func (a *App) DeleteRemoteTag(remote, name string) error {
+	if err := a.checkTagRemote(remote, name); err != nil {
+		return err
+	}
+
+	if _, err := a.executor.Execute("push", remote, "--delete", tagRefPrefix+name); err != nil {
+		return fmt.Errorf("failed to delete tag %s from %s: %w", name, remote, err)
+	}
+	return nil
+}
+
+// checkTagName checks a tag name against git's ref format rules.
+func (a *App) checkTagName(name string) error {
+	if a.executor == nil {
+		return errors.New("no repository initialized")
+	}
+	if strings.TrimSpace(name) == "" {
+		return errors.New("tag name required")
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid tag name %q", name)
+	}
+
+	if _, err := a.executor.Execute("check-ref-format", tagRefPrefix+name); err != nil {
+		return fmt.Errorf("invalid tag name %q", name)
+	}
+	return nil
+}
+
+// checkTagRemote validates the arguments of the remote tag operations.
+func (a *App) checkTagRemote(remote, name string) error {
+	if remote == "" || strings.HasPrefix(remote, "-") {
+		return fmt.Errorf("invalid remote %q", remote)
+	}
+	return a.checkTagName(name)
+}
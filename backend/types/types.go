@@ -13,8 +13,9 @@ const (
 
 // GitRepo represents the git repository state.
 type GitRepo struct {
-	Path          string `json:"Path"`
-	CurrentBranch string `json:"CurrentBranch"`
+	Path          string    `json:"Path"`
+	CurrentBranch string    `json:"CurrentBranch"`
+	HeadState     HeadState `json:"HeadState"`
 }
 
 // FileStatus represents a single file's git status.
@@ -31,26 +32,687 @@ type Branch struct {
 	IsRemote  bool   `json:"IsRemote"`
 }
 
+// DiffMode selects which two sides of a file a diff compares.
+type DiffMode string
+
+const (
+	DiffWorktreeToIndex DiffMode = "worktree-index"
+	DiffIndexToHead     DiffMode = "index-head"
+	DiffWorktreeToHead  DiffMode = "worktree-head"
+	DiffRevisions       DiffMode = "revisions"
+)
+
+// DiffAlgorithm selects the algorithm git uses to compute a diff.
+type DiffAlgorithm string
+
+const (
+	AlgorithmDefault   DiffAlgorithm = ""
+	AlgorithmMyers     DiffAlgorithm = "myers"
+	AlgorithmMinimal   DiffAlgorithm = "minimal"
+	AlgorithmPatience  DiffAlgorithm = "patience"
+	AlgorithmHistogram DiffAlgorithm = "histogram"
+)
+
+// DiffOptions tune how diffs are computed. The zero value uses git's
+// defaults; a nil ContextLines keeps git's default amount of context.
+type DiffOptions struct {
+	IgnoreAllSpace    bool          `json:"IgnoreAllSpace"`
+	IgnoreSpaceChange bool          `json:"IgnoreSpaceChange"`
+	IgnoreBlankLines  bool          `json:"IgnoreBlankLines"`
+	ContextLines      *int          `json:"ContextLines"`
+	Algorithm         DiffAlgorithm `json:"Algorithm"`
+	WordDiff          bool          `json:"WordDiff"`
+	FunctionContext   bool          `json:"FunctionContext"`
+}
+
+// DiffTarget describes the two sides of a diff. From and To are only used
+// by DiffRevisions; an empty To compares From against the working tree.
+type DiffTarget struct {
+	Mode DiffMode `json:"Mode"`
+	From string   `json:"From"`
+	To   string   `json:"To"`
+}
+
+// FileDiffs holds the staged and unstaged changes of a single file.
+type FileDiffs struct {
+	FilePath string      `json:"FilePath"`
+	Staged   *DiffResult `json:"Staged"`
+	Unstaged *DiffResult `json:"Unstaged"`
+}
+
+// DiffSummary counts the changes in a diff without needing its hunks.
+type DiffSummary struct {
+	FilesChanged int `json:"FilesChanged"`
+	Insertions   int `json:"Insertions"`
+	Deletions    int `json:"Deletions"`
+	HunkCount    int `json:"HunkCount"`
+}
+
+// DiffHunkPage is a window of the hunks of a file's diff, used to load
+// large diffs incrementally.
+type DiffHunkPage struct {
+	FilePath   string     `json:"FilePath"`
+	Offset     int        `json:"Offset"`
+	TotalHunks int        `json:"TotalHunks"`
+	Hunks      []DiffHunk `json:"Hunks"`
+}
+
 // DiffResult represents the diff output for a file.
+// Mode, blob and symlink fields are taken from the extended diff header.
+// A Truncated result was too large to send whole and only has its Summary;
+// its hunks can be paged in separately.
 type DiffResult struct {
-	FilePath string     `json:"FilePath"`
-	Diff     string     `json:"Diff"`
-	Hunks    []DiffHunk `json:"Hunks"`
+	FilePath         string      `json:"FilePath"`
+	Mode             DiffMode    `json:"Mode"`
+	Diff             string      `json:"Diff"`
+	Hunks            []DiffHunk  `json:"Hunks"`
+	Summary          DiffSummary `json:"Summary"`
+	Truncated        bool        `json:"Truncated"`
+	IsNew            bool        `json:"IsNew"`
+	IsDeleted        bool        `json:"IsDeleted"`
+	OldMode          string      `json:"OldMode"`
+	NewMode          string      `json:"NewMode"`
+	OldBlob          string      `json:"OldBlob"`
+	NewBlob          string      `json:"NewBlob"`
+	IsBinary         bool        `json:"IsBinary"`
+	Binary           *BinaryInfo `json:"Binary"`
+	IsSymlink        bool        `json:"IsSymlink"`
+	OldSymlinkTarget string      `json:"OldSymlinkTarget"`
+	NewSymlinkTarget string      `json:"NewSymlinkTarget"`
 }
 
 // DiffHunk represents an individual change block in a diff.
 type DiffHunk struct {
-	Header   string   `json:"Header"`
-	OldStart int      `json:"OldStart"`
-	OldLines int      `json:"OldLines"`
-	NewStart int      `json:"NewStart"`
-	NewLines int      `json:"NewLines"`
-	Lines    []string `json:"Lines"`
+	Header   string     `json:"Header"`
+	OldStart int        `json:"OldStart"`
+	OldLines int        `json:"OldLines"`
+	NewStart int        `json:"NewStart"`
+	NewLines int        `json:"NewLines"`
+	Section  string     `json:"Section"`
+	Lines    []DiffLine `json:"Lines"`
 }
 
-// CommitResult represents the result of a commit operation.
+// DiffLineKind identifies the role of a line within a hunk.
+type DiffLineKind string
+
+const (
+	LineContext   DiffLineKind = "context"
+	LineAdd       DiffLineKind = "add"
+	LineDelete    DiffLineKind = "delete"
+	LineNoNewline DiffLineKind = "no-newline"
+	LineModified  DiffLineKind = "modified"
+)
+
+// DiffLine is a single line of a hunk with its prefix removed. Line numbers
+// are zero on the side the line does not exist in. Word diffs fill Segments
+// and use LineModified for lines that mix added and deleted words; their
+// Content is the new text of the line.
+type DiffLine struct {
+	Kind      DiffLineKind  `json:"Kind"`
+	OldLineNo int           `json:"OldLineNo"`
+	NewLineNo int           `json:"NewLineNo"`
+	Content   string        `json:"Content"`
+	Changes   []LineSpan    `json:"Changes"`
+	Segments  []WordSegment `json:"Segments"`
+}
+
+// WordSegment is a run of text within a word diff line. Kind is one of
+// LineContext, LineAdd or LineDelete.
+type WordSegment struct {
+	Kind DiffLineKind `json:"Kind"`
+	Text string       `json:"Text"`
+}
+
+// LineSpan marks the changed part of a line as a half-open range of
+// character (rune) offsets into its Content.
+type LineSpan struct {
+	Start int `json:"Start"`
+	End   int `json:"End"`
+}
+
+// CommitResult represents the result of a commit operation. Stats is nil
+// when the size of the commit could not be determined.
 type CommitResult struct {
-	Success   bool   `json:"Success"`
-	CommitSHA string `json:"CommitSHA"`
-	Message   string `json:"Message"`
+	Success   bool      `json:"Success"`
+	CommitSHA string    `json:"CommitSHA"`
+	Message   string    `json:"Message"`
+	Stats     *DiffStat `json:"Stats"`
+}
+
+// BranchNamePolicy describes naming rules that new branch names must follow.
+// An empty policy accepts any name that git itself accepts.
+type BranchNamePolicy struct {
+	Prefixes      []string `json:"Prefixes"`
+	TicketPattern string   `json:"TicketPattern"`
+}
+
+// RepoOperation identifies a multi-step git operation that is in progress.
+type RepoOperation string
+
+const (
+	OperationNone       RepoOperation = ""
+	OperationMerge      RepoOperation = "merge"
+	OperationRebase     RepoOperation = "rebase"
+	OperationCherryPick RepoOperation = "cherry-pick"
+	OperationRevert     RepoOperation = "revert"
+	OperationBisect     RepoOperation = "bisect"
+)
+
+// HeadState describes what HEAD points at and any operation in progress.
+// During a rebase Branch holds the branch being rebased.
+type HeadState struct {
+	Branch     string        `json:"Branch"`
+	Detached   bool          `json:"Detached"`
+	DetachedAt string        `json:"DetachedAt"`
+	Unborn     bool          `json:"Unborn"`
+	Operation  RepoOperation `json:"Operation"`
+	Step       int           `json:"Step"`
+	TotalSteps int           `json:"TotalSteps"`
+}
+
+// OperationState describes a multi-step operation in progress and the
+// paths that still have unresolved conflicts.
+type OperationState struct {
+	Operation  RepoOperation `json:"Operation"`
+	Branch     string        `json:"Branch"`
+	Step       int           `json:"Step"`
+	TotalSteps int           `json:"TotalSteps"`
+	Conflicts  []string      `json:"Conflicts"`
+	CanSkip    bool          `json:"CanSkip"`
+}
+
+// BinaryInfo describes both sides of a binary file change, for which git
+// produces no textual diff.
+type BinaryInfo struct {
+	OldSize  int64  `json:"OldSize"`
+	NewSize  int64  `json:"NewSize"`
+	MimeType string `json:"MimeType"`
+}
+
+// FileLines is a range of lines read from one version of a file, used to
+// expand the context around diff hunks. Start and End are 1-based and
+// inclusive; TotalLines is the length of the whole file.
+type FileLines struct {
+	Path       string   `json:"Path"`
+	Revision   string   `json:"Revision"`
+	Start      int      `json:"Start"`
+	End        int      `json:"End"`
+	TotalLines int      `json:"TotalLines"`
+	Lines      []string `json:"Lines"`
+}
+
+// TokenSpan is a syntax-highlighted range of a line, as rune offsets into
+// the line content. Class is a chroma CSS class such as "k" or "s".
+type TokenSpan struct {
+	Start int    `json:"Start"`
+	End   int    `json:"End"`
+	Class string `json:"Class"`
+}
+
+// LineHighlight holds the syntax tokens of one diff line.
+type LineHighlight struct {
+	Tokens []TokenSpan `json:"Tokens"`
+}
+
+// HunkHighlight holds the syntax tokens of each line of a hunk, in the same
+// order as DiffHunk.Lines.
+type HunkHighlight struct {
+	Lines []LineHighlight `json:"Lines"`
+}
+
+// DiffHighlight holds syntax highlighting for a DiffResult, hunk by hunk.
+type DiffHighlight struct {
+	FilePath string          `json:"FilePath"`
+	Language string          `json:"Language"`
+	Hunks    []HunkHighlight `json:"Hunks"`
+}
+
+// Snapshot is a saved copy of working tree files taken before they were
+// discarded, so the discard can be undone.
+type Snapshot struct {
+	ID        string   `json:"ID"`
+	Reason    string   `json:"Reason"`
+	CreatedAt int64    `json:"CreatedAt"`
+	Paths     []string `json:"Paths"`
+}
+
+// DiscardResult reports the outcome of discarding working tree changes.
+// Deleting an untracked file first returns NeedsConfirmation with a token
+// that must be passed back to confirm the deletion.
+type DiscardResult struct {
+	Paths             []string `json:"Paths"`
+	SnapshotID        string   `json:"SnapshotID"`
+	NeedsConfirmation bool     `json:"NeedsConfirmation"`
+	ConfirmToken      string   `json:"ConfirmToken"`
+}
+
+// CleanResult lists the untracked files removed, or that would be removed
+// in a dry run.
+type CleanResult struct {
+	Paths      []string `json:"Paths"`
+	DryRun     bool     `json:"DryRun"`
+	SnapshotID string   `json:"SnapshotID"`
+}
+
+// RepoState is the part of a repository's state the operation journal
+// restores on undo and redo. Head is the ref HEAD points to, or empty when
+// HEAD is detached. Refs maps each ref the operation touched to its SHA,
+// which is empty when the ref does not exist.
+type RepoState struct {
+	Head       string            `json:"Head"`
+	HeadSHA    string            `json:"HeadSHA"`
+	IndexTree  string            `json:"IndexTree"`
+	Refs       map[string]string `json:"Refs"`
+	SnapshotID string            `json:"SnapshotID"`
+}
+
+// JournalEntry records the state of the repository before and after a
+// mutating operation. Paths lists the working tree files it changed, whose
+// content is kept in the snapshots of Before and After.
+type JournalEntry struct {
+	ID          int       `json:"ID"`
+	Operation   string    `json:"Operation"`
+	Description string    `json:"Description"`
+	Timestamp   int64     `json:"Timestamp"`
+	Paths       []string  `json:"Paths"`
+	Before      RepoState `json:"Before"`
+	After       RepoState `json:"After"`
+	Undone      bool      `json:"Undone"`
+}
+
+// ResetMode selects how `git reset` treats the index and working tree.
+type ResetMode string
+
+const (
+	ResetSoft  ResetMode = "soft"
+	ResetMixed ResetMode = "mixed"
+	ResetHard  ResetMode = "hard"
+	ResetKeep  ResetMode = "keep"
+)
+
+// CommitSummary identifies a commit by SHA and subject line.
+type CommitSummary struct {
+	SHA     string `json:"SHA"`
+	Subject string `json:"Subject"`
+}
+
+// ResetPreflight lists what a reset would take away: commits that leave the
+// current branch without being on any remote, and files whose uncommitted
+// changes a hard reset would overwrite.
+type ResetPreflight struct {
+	Revision        string          `json:"Revision"`
+	Mode            ResetMode       `json:"Mode"`
+	CurrentSHA      string          `json:"CurrentSHA"`
+	TargetSHA       string          `json:"TargetSHA"`
+	UnpushedCommits []CommitSummary `json:"UnpushedCommits"`
+	DiscardedFiles  []string        `json:"DiscardedFiles"`
+}
+
+// ResetResult reports a completed reset. RecoveryRef is set after a hard
+// reset and points at the previous HEAD along with any uncommitted changes.
+type ResetResult struct {
+	Mode        ResetMode `json:"Mode"`
+	PreviousSHA string    `json:"PreviousSHA"`
+	TargetSHA   string    `json:"TargetSHA"`
+	RecoveryRef string    `json:"RecoveryRef"`
+}
+
+// RebaseAction is the command of a step in an interactive rebase todo list.
+type RebaseAction string
+
+const (
+	RebasePick   RebaseAction = "pick"
+	RebaseReword RebaseAction = "reword"
+	RebaseEdit   RebaseAction = "edit"
+	RebaseSquash RebaseAction = "squash"
+	RebaseFixup  RebaseAction = "fixup"
+	RebaseDrop   RebaseAction = "drop"
+	RebaseExec   RebaseAction = "exec"
+)
+
+// RebaseStep is one entry of a rebase plan. Exec steps run Command instead
+// of applying a commit. Message replaces the commit message of a reword,
+// or the combined message of a squash chain when set on one of its squash
+// steps.
+type RebaseStep struct {
+	Action  RebaseAction `json:"Action"`
+	SHA     string       `json:"SHA"`
+	Subject string       `json:"Subject"`
+	Command string       `json:"Command"`
+	Message string       `json:"Message"`
+}
+
+// RebasePlan is the todo list of an interactive rebase of HeadSHA onto
+// Onto, which resolves to OntoSHA.
+type RebasePlan struct {
+	Onto    string       `json:"Onto"`
+	OntoSHA string       `json:"OntoSHA"`
+	HeadSHA string       `json:"HeadSHA"`
+	Steps   []RebaseStep `json:"Steps"`
+}
+
+// RebaseStop explains why an interactive rebase paused.
+type RebaseStop string
+
+const (
+	RebaseStopNone     RebaseStop = ""
+	RebaseStopConflict RebaseStop = "conflict"
+	RebaseStopEdit     RebaseStop = "edit"
+	RebaseStopExec     RebaseStop = "exec"
+	RebaseStopOther    RebaseStop = "other"
+)
+
+// RebaseResult reports how far a rebase got. When it stopped, Step is the
+// step it stopped at and StoppedAt the commit or command of that step; the
+// rebase is then resumed or abandoned with ContinueOperation,
+// SkipOperation or AbortOperation.
+type RebaseResult struct {
+	Completed  bool       `json:"Completed"`
+	Stop       RebaseStop `json:"Stop"`
+	StoppedAt  string     `json:"StoppedAt"`
+	Step       int        `json:"Step"`
+	TotalSteps int        `json:"TotalSteps"`
+	Conflicts  []string   `json:"Conflicts"`
+}
+
+// CherryPickOptions tune CherryPick. RecordOrigin appends a "(cherry picked
+// from commit ...)" line to each message, Mainline selects the parent a
+// merge commit is diffed against, and NoCommit only applies the changes to
+// the index and working tree.
+type CherryPickOptions struct {
+	RecordOrigin bool `json:"RecordOrigin"`
+	Mainline     int  `json:"Mainline"`
+	NoCommit     bool `json:"NoCommit"`
+}
+
+// RevertOptions tune Revert, with the same meaning as in CherryPickOptions.
+type RevertOptions struct {
+	Mainline int  `json:"Mainline"`
+	NoCommit bool `json:"NoCommit"`
+}
+
+// PickResult reports the outcome of a cherry-pick or revert. When it stopped,
+// StoppedAt is the commit that could not be applied and the operation stays
+// in progress until it is continued, skipped or aborted. A run without
+// commits that conflicts leaves only the conflicts in the index, so
+// StoppedAt is empty.
+type PickResult struct {
+	Operation RepoOperation `json:"Operation"`
+	Completed bool          `json:"Completed"`
+	HeadSHA   string        `json:"HeadSHA"`
+	StoppedAt string        `json:"StoppedAt"`
+	Conflicts []string      `json:"Conflicts"`
+}
+
+// FastForwardMode selects whether a merge may, must or must not fast-forward.
+type FastForwardMode string
+
+const (
+	FastForwardAllow FastForwardMode = ""
+	FastForwardOnly  FastForwardMode = "only"
+	FastForwardNever FastForwardMode = "never"
+)
+
+// MergeStrategyOption is passed to the merge strategy with -X to settle
+// conflicting hunks in favour of one side.
+type MergeStrategyOption string
+
+const (
+	StrategyDefault MergeStrategyOption = ""
+	StrategyOurs    MergeStrategyOption = "ours"
+	StrategyTheirs  MergeStrategyOption = "theirs"
+)
+
+// MergeOptions tune Merge. Squash stages the combined changes without
+// committing them, and Message replaces the default merge commit message.
+type MergeOptions struct {
+	FastForward    FastForwardMode     `json:"FastForward"`
+	Squash         bool                `json:"Squash"`
+	Message        string              `json:"Message"`
+	StrategyOption MergeStrategyOption `json:"StrategyOption"`
+}
+
+// MergeOutcome describes what a merge did, or would do when previewed.
+type MergeOutcome string
+
+const (
+	MergeUpToDate    MergeOutcome = "up-to-date"
+	MergeFastForward MergeOutcome = "fast-forward"
+	MergeCommitted   MergeOutcome = "merged"
+	MergeSquashed    MergeOutcome = "squashed"
+	MergeConflict    MergeOutcome = "conflict"
+)
+
+// MergeResult reports the outcome of a merge. On conflict the merge stays in
+// progress, except for squash merges, and Conflicts lists the paths to
+// resolve.
+type MergeResult struct {
+	Branch    string       `json:"Branch"`
+	Outcome   MergeOutcome `json:"Outcome"`
+	HeadSHA   string       `json:"HeadSHA"`
+	Conflicts []string     `json:"Conflicts"`
+}
+
+// MergePreview predicts the outcome of merging Branch into HEAD. Tree is the
+// tree the merge would produce, with conflict markers in conflicted files.
+type MergePreview struct {
+	Branch    string       `json:"Branch"`
+	HeadSHA   string       `json:"HeadSHA"`
+	BranchSHA string       `json:"BranchSHA"`
+	Outcome   MergeOutcome `json:"Outcome"`
+	Tree      string       `json:"Tree"`
+	Conflicts []string     `json:"Conflicts"`
+}
+
+// Tag is a tag in refs/tags. Target is the commit the tag points at, and the
+// tagger fields and Message are only set for annotated tags. Date is the
+// tagger date of annotated tags and the commit date of lightweight ones.
+type Tag struct {
+	Name        string `json:"Name"`
+	Target      string `json:"Target"`
+	Annotated   bool   `json:"Annotated"`
+	Signed      bool   `json:"Signed"`
+	Tagger      string `json:"Tagger"`
+	TaggerEmail string `json:"TaggerEmail"`
+	Date        int64  `json:"Date"`
+	Message     string `json:"Message"`
+}
+
+// LineRange selects lines Start to End of a file, counting from 1. A zero
+// End runs to the end of the file, and a zero range selects the whole file.
+type LineRange struct {
+	Start int `json:"Start"`
+	End   int `json:"End"`
+}
+
+// BlameOptions tune GetBlame. DetectMoves and DetectCopies follow lines
+// moved within a file and copied from other files of the same commit, and
+// IgnoreRevsFile names a file of commits to look through, such as
+// reformatting commits.
+type BlameOptions struct {
+	IgnoreWhitespace bool   `json:"IgnoreWhitespace"`
+	DetectMoves      bool   `json:"DetectMoves"`
+	DetectCopies     bool   `json:"DetectCopies"`
+	IgnoreRevsFile   string `json:"IgnoreRevsFile"`
+}
+
+// BlameLine attributes one line of a file to the commit that last changed
+// it. OriginalLine and OriginalPath locate the line in that commit. Lines
+// that are not committed yet have an all-zero SHA.
+type BlameLine struct {
+	LineNumber   int    `json:"LineNumber"`
+	OriginalLine int    `json:"OriginalLine"`
+	OriginalPath string `json:"OriginalPath"`
+	SHA          string `json:"SHA"`
+	Author       string `json:"Author"`
+	AuthorEmail  string `json:"AuthorEmail"`
+	AuthorTime   int64  `json:"AuthorTime"`
+	Summary      string `json:"Summary"`
+	Content      string `json:"Content"`
+}
+
+// FileHistoryEntry is one commit in the history of a file. Path is the
+// file's name in that commit and OldPath its name before a rename. Diff is
+// the change the commit made to the file.
+type FileHistoryEntry struct {
+	SHA         string      `json:"SHA"`
+	Author      string      `json:"Author"`
+	AuthorEmail string      `json:"AuthorEmail"`
+	AuthorTime  int64       `json:"AuthorTime"`
+	Subject     string      `json:"Subject"`
+	Path        string      `json:"Path"`
+	OldPath     string      `json:"OldPath"`
+	Status      StatusType  `json:"Status"`
+	Diff        *DiffResult `json:"Diff"`
+}
+
+// GraphCommit is a commit of the commit graph with its parents in order,
+// the first parent being the one the commit was made on.
+type GraphCommit struct {
+	SHA        string   `json:"SHA"`
+	Parents    []string `json:"Parents"`
+	Author     string   `json:"Author"`
+	AuthorTime int64    `json:"AuthorTime"`
+	Subject    string   `json:"Subject"`
+}
+
+// GraphEdge is a line of the commit graph from column From of the previous
+// row to column To of the current row. Color identifies the lane the line
+// belongs to and stays the same along a lane.
+type GraphEdge struct {
+	From  int `json:"From"`
+	To    int `json:"To"`
+	Color int `json:"Color"`
+}
+
+// GraphRow places a commit in the commit graph. Edges are the lines coming
+// in from the row above, and Width is the number of columns they and the
+// commit span. Merge marks commits with several parents and Fork commits
+// that several lines end at because they have several children.
+type GraphRow struct {
+	Commit GraphCommit `json:"Commit"`
+	Column int         `json:"Column"`
+	Color  int         `json:"Color"`
+	Edges  []GraphEdge `json:"Edges"`
+	Width  int         `json:"Width"`
+	Merge  bool        `json:"Merge"`
+	Fork   bool        `json:"Fork"`
+}
+
+// GraphPage is a page of commit graph rows starting at row Offset.
+type GraphPage struct {
+	Offset  int        `json:"Offset"`
+	Rows    []GraphRow `json:"Rows"`
+	HasMore bool       `json:"HasMore"`
+}
+
+// SearchMode selects what Search looks through.
+type SearchMode string
+
+const (
+	SearchMessage      SearchMode = "message"
+	SearchAuthor       SearchMode = "author"
+	SearchPickaxe      SearchMode = "pickaxe"
+	SearchPickaxeRegex SearchMode = "pickaxe-regex"
+	SearchContent      SearchMode = "content"
+)
+
+// SearchQuery describes a search. Revision limits commit searches to its
+// history, HEAD by default, and makes content searches read that revision
+// instead of the working tree. Fixed matches Pattern literally instead of
+// as a regular expression, except for pickaxe searches, where the mode
+// decides. Context is the number of lines shown around content matches.
+// Limit caps the number of commits or matches returned.
+type SearchQuery struct {
+	Mode       SearchMode `json:"Mode"`
+	Pattern    string     `json:"Pattern"`
+	Revision   string     `json:"Revision"`
+	Paths      []string   `json:"Paths"`
+	IgnoreCase bool       `json:"IgnoreCase"`
+	Fixed      bool       `json:"Fixed"`
+	Context    int        `json:"Context"`
+	Limit      int        `json:"Limit"`
+}
+
+// SearchCommit is a commit found by a commit search. For pickaxe searches
+// Files lists the files in which the number of occurrences changed.
+type SearchCommit struct {
+	SHA         string   `json:"SHA"`
+	Author      string   `json:"Author"`
+	AuthorEmail string   `json:"AuthorEmail"`
+	AuthorTime  int64    `json:"AuthorTime"`
+	Subject     string   `json:"Subject"`
+	Files       []string `json:"Files"`
+}
+
+// ContentMatch is a line matched by a content search. Column counts from 1,
+// and Before and After hold the context lines directly around the match.
+type ContentMatch struct {
+	Path       string   `json:"Path"`
+	LineNumber int      `json:"LineNumber"`
+	Column     int      `json:"Column"`
+	Content    string   `json:"Content"`
+	Before     []string `json:"Before"`
+	After      []string `json:"After"`
+}
+
+// SearchResult holds the commits of a commit search or the matches of a
+// content search. Truncated reports that Limit cut the results short.
+type SearchResult struct {
+	Mode      SearchMode     `json:"Mode"`
+	Commits   []SearchCommit `json:"Commits"`
+	Matches   []ContentMatch `json:"Matches"`
+	Truncated bool           `json:"Truncated"`
+}
+
+// ComparedFile is a file changed between the two sides of a comparison.
+// OldPath is set for renames.
+type ComparedFile struct {
+	Path    string      `json:"Path"`
+	OldPath string      `json:"OldPath"`
+	Status  StatusType  `json:"Status"`
+	Diff    *DiffResult `json:"Diff"`
+}
+
+// Comparison describes what Head adds on top of Base, the way a pull request
+// would show it. Ahead lists the commits only on Head and Behind those only
+// on Base, newest first. Files are diffed from MergeBase to Head, Summary
+// adds up their changes and Stats counts lines per file as git diff
+// --numstat does.
+type Comparison struct {
+	Base      string          `json:"Base"`
+	Head      string          `json:"Head"`
+	BaseSHA   string          `json:"BaseSHA"`
+	HeadSHA   string          `json:"HeadSHA"`
+	MergeBase string          `json:"MergeBase"`
+	Ahead     []CommitSummary `json:"Ahead"`
+	Behind    []CommitSummary `json:"Behind"`
+	Files     []ComparedFile  `json:"Files"`
+	Summary   DiffSummary     `json:"Summary"`
+	Stats     DiffStat        `json:"Stats"`
+}
+
+// FileStat counts the lines added and removed in a file. Binary files have
+// no line counts. OldPath is set for renames.
+type FileStat struct {
+	Path       string `json:"Path"`
+	OldPath    string `json:"OldPath"`
+	Insertions int    `json:"Insertions"`
+	Deletions  int    `json:"Deletions"`
+	Binary     bool   `json:"Binary"`
+}
+
+// DiffStat holds the per-file line counts of a diff and their totals.
+type DiffStat struct {
+	Files        []FileStat `json:"Files"`
+	FilesChanged int        `json:"FilesChanged"`
+	Insertions   int        `json:"Insertions"`
+	Deletions    int        `json:"Deletions"`
+}
+
+// ChangeStats sizes the staged and unstaged changes of the working tree.
+// Untracked files are counted as unstaged additions.
+type ChangeStats struct {
+	Staged   DiffStat `json:"Staged"`
+	Unstaged DiffStat `json:"Unstaged"`
 }
@@ -2,28 +2,128 @@
 // This file is automatically generated. DO NOT EDIT
 import {types} from '../models';
 
+export function AbortOperation():Promise<types.OperationState>;
+
+export function CherryPick(arg1:Array<string>,arg2:types.CherryPickOptions):Promise<types.PickResult>;
+
+export function CleanUntracked(arg1:Array<string>,arg2:boolean):Promise<types.CleanResult>;
+
 export function CommitAndPush(arg1:Array<string>,arg2:string):Promise<types.CommitResult>;
 
 export function CommitFiles(arg1:Array<string>,arg2:string):Promise<types.CommitResult>;
 
+export function CommitIndex(arg1:string):Promise<types.CommitResult>;
+
+export function Compare(arg1:string,arg2:string):Promise<types.Comparison>;
+
+export function ContinueOperation():Promise<types.OperationState>;
+
 export function CreateBranch(arg1:string):Promise<void>;
 
+export function CreateBranchFrom(arg1:string,arg2:string,arg3:boolean,arg4:boolean):Promise<void>;
+
+export function CreateTag(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<void>;
+
+export function DeleteRemoteTag(arg1:string,arg2:string):Promise<void>;
+
+export function DeleteTag(arg1:string):Promise<void>;
+
+export function DiscardFile(arg1:string,arg2:string):Promise<types.DiscardResult>;
+
+export function DiscardHunk(arg1:string,arg2:number):Promise<types.DiscardResult>;
+
+export function DiscardLines(arg1:string,arg2:number,arg3:Array<number>):Promise<types.DiscardResult>;
+
+export function ExecuteRebase(arg1:types.RebasePlan):Promise<types.RebaseResult>;
+
+export function GetBlame(arg1:string,arg2:string,arg3:types.LineRange,arg4:types.BlameOptions):Promise<Array<types.BlameLine>>;
+
+export function GetBranchNamePolicy():Promise<types.BranchNamePolicy>;
+
 export function GetBranches():Promise<Array<types.Branch>>;
 
+export function GetChangeStats():Promise<types.ChangeStats>;
+
+export function GetCommitGraph(arg1:number,arg2:number):Promise<types.GraphPage>;
+
 export function GetCurrentBranch():Promise<string>;
 
 export function GetCurrentRepo():Promise<types.GitRepo>;
 
+export function GetDiff(arg1:string,arg2:types.DiffTarget):Promise<types.DiffResult>;
+
+export function GetDiffHighlight(arg1:string,arg2:types.DiffTarget):Promise<types.DiffHighlight>;
+
+export function GetDiffHunks(arg1:string,arg2:types.DiffTarget,arg3:number,arg4:number):Promise<types.DiffHunkPage>;
+
+export function GetDiffHunksHighlight(arg1:string,arg2:types.DiffTarget,arg3:number,arg4:number):Promise<types.DiffHighlight>;
+
+export function GetDiffOptions():Promise<types.DiffOptions>;
+
+export function GetFileDiffs(arg1:string):Promise<types.FileDiffs>;
+
+export function GetFileHistory(arg1:string,arg2:number,arg3:number):Promise<Array<types.FileHistoryEntry>>;
+
+export function GetFileLines(arg1:string,arg2:string,arg3:number,arg4:number):Promise<types.FileLines>;
+
 export function GetGitDiff(arg1:string):Promise<types.DiffResult>;
 
 export function GetGitStatus():Promise<Array<types.FileStatus>>;
 
+export function GetHeadState():Promise<types.HeadState>;
+
+export function GetOperationHistory():Promise<Array<types.JournalEntry>>;
+
+export function GetOperationState():Promise<types.OperationState>;
+
 export function GetRepoRoot():Promise<string>;
 
 export function InitRepo(arg1:string):Promise<void>;
 
+export function ListSnapshots():Promise<Array<types.Snapshot>>;
+
+export function ListTags():Promise<Array<types.Tag>>;
+
+export function Merge(arg1:string,arg2:types.MergeOptions):Promise<types.MergeResult>;
+
+export function PlanRebase(arg1:string):Promise<types.RebasePlan>;
+
+export function PreviewMerge(arg1:string):Promise<types.MergePreview>;
+
+export function PreviewReset(arg1:string,arg2:types.ResetMode):Promise<types.ResetPreflight>;
+
 export function PushChanges():Promise<void>;
 
+export function PushTag(arg1:string,arg2:string):Promise<void>;
+
+export function Redo():Promise<types.JournalEntry>;
+
+export function ResetTo(arg1:string,arg2:types.ResetMode):Promise<types.ResetResult>;
+
+export function RestoreSnapshot(arg1:string):Promise<void>;
+
+export function Revert(arg1:Array<string>,arg2:types.RevertOptions):Promise<types.PickResult>;
+
+export function Search(arg1:types.SearchQuery):Promise<types.SearchResult>;
+
+export function SetBranchNamePolicy(arg1:types.BranchNamePolicy):Promise<void>;
+
+export function SetDiffOptions(arg1:types.DiffOptions):Promise<void>;
+
+export function SkipOperation():Promise<types.OperationState>;
+
+export function StageAll():Promise<void>;
+
+export function StageFiles(arg1:Array<string>):Promise<void>;
+
 export function SwitchBranch(arg1:string):Promise<void>;
 
+export function Undo():Promise<types.JournalEntry>;
+
+export function UnstageAll():Promise<void>;
+
+export function UnstageFiles(arg1:Array<string>):Promise<void>;
+
+export function ValidateBranchName(arg1:string):Promise<void>;
+
 export function ValidateRepo(arg1:string):Promise<boolean>;
@@ -2,6 +2,18 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AbortOperation() {
+  return window['go']['backend']['App']['AbortOperation']();
+}
+
+export function CherryPick(arg1, arg2) {
+  return window['go']['backend']['App']['CherryPick'](arg1, arg2);
+}
+
+export function CleanUntracked(arg1, arg2) {
+  return window['go']['backend']['App']['CleanUntracked'](arg1, arg2);
+}
+
 export function CommitAndPush(arg1, arg2) {
   return window['go']['backend']['App']['CommitAndPush'](arg1, arg2);
 }
@@ -10,14 +22,74 @@ export function CommitFiles(arg1, arg2) {
   return window['go']['backend']['App']['CommitFiles'](arg1, arg2);
 }
 
+export function CommitIndex(arg1) {
+  return window['go']['backend']['App']['CommitIndex'](arg1);
+}
+
+export function Compare(arg1, arg2) {
+  return window['go']['backend']['App']['Compare'](arg1, arg2);
+}
+
+export function ContinueOperation() {
+  return window['go']['backend']['App']['ContinueOperation']();
+}
+
 export function CreateBranch(arg1) {
   return window['go']['backend']['App']['CreateBranch'](arg1);
 }
 
+export function CreateBranchFrom(arg1, arg2, arg3, arg4) {
+  return window['go']['backend']['App']['CreateBranchFrom'](arg1, arg2, arg3, arg4);
+}
+
+export function CreateTag(arg1, arg2, arg3, arg4) {
+  return window['go']['backend']['App']['CreateTag'](arg1, arg2, arg3, arg4);
+}
+
+export function DeleteRemoteTag(arg1, arg2) {
+  return window['go']['backend']['App']['DeleteRemoteTag'](arg1, arg2);
+}
+
+export function DeleteTag(arg1) {
+  return window['go']['backend']['App']['DeleteTag'](arg1);
+}
+
+export function DiscardFile(arg1, arg2) {
+  return window['go']['backend']['App']['DiscardFile'](arg1, arg2);
+}
+
+export function DiscardHunk(arg1, arg2) {
+  return window['go']['backend']['App']['DiscardHunk'](arg1, arg2);
+}
+
+export function DiscardLines(arg1, arg2, arg3) {
+  return window['go']['backend']['App']['DiscardLines'](arg1, arg2, arg3);
+}
+
+export function ExecuteRebase(arg1) {
+  return window['go']['backend']['App']['ExecuteRebase'](arg1);
+}
+
+export function GetBlame(arg1, arg2, arg3, arg4) {
+  return window['go']['backend']['App']['GetBlame'](arg1, arg2, arg3, arg4);
+}
+
+export function GetBranchNamePolicy() {
+  return window['go']['backend']['App']['GetBranchNamePolicy']();
+}
+
 export function GetBranches() {
   return window['go']['backend']['App']['GetBranches']();
 }
 
+export function GetChangeStats() {
+  return window['go']['backend']['App']['GetChangeStats']();
+}
+
+export function GetCommitGraph(arg1, arg2) {
+  return window['go']['backend']['App']['GetCommitGraph'](arg1, arg2);
+}
+
 export function GetCurrentBranch() {
   return window['go']['backend']['App']['GetCurrentBranch']();
 }
@@ -26,6 +98,38 @@ export function GetCurrentRepo() {
   return window['go']['backend']['App']['GetCurrentRepo']();
 }
 
+export function GetDiff(arg1, arg2) {
+  return window['go']['backend']['App']['GetDiff'](arg1, arg2);
+}
+
+export function GetDiffHighlight(arg1, arg2) {
+  return window['go']['backend']['App']['GetDiffHighlight'](arg1, arg2);
+}
+
+export function GetDiffHunks(arg1, arg2, arg3, arg4) {
+  return window['go']['backend']['App']['GetDiffHunks'](arg1, arg2, arg3, arg4);
+}
+
+export function GetDiffHunksHighlight(arg1, arg2, arg3, arg4) {
+  return window['go']['backend']['App']['GetDiffHunksHighlight'](arg1, arg2, arg3, arg4);
+}
+
+export function GetDiffOptions() {
+  return window['go']['backend']['App']['GetDiffOptions']();
+}
+
+export function GetFileDiffs(arg1) {
+  return window['go']['backend']['App']['GetFileDiffs'](arg1);
+}
+
+export function GetFileHistory(arg1, arg2, arg3) {
+  return window['go']['backend']['App']['GetFileHistory'](arg1, arg2, arg3);
+}
+
+export function GetFileLines(arg1, arg2, arg3, arg4) {
+  return window['go']['backend']['App']['GetFileLines'](arg1, arg2, arg3, arg4);
+}
+
 export function GetGitDiff(arg1) {
   return window['go']['backend']['App']['GetGitDiff'](arg1);
 }
@@ -34,6 +138,18 @@ export function GetGitStatus() {
   return window['go']['backend']['App']['GetGitStatus']();
 }
 
+export function GetHeadState() {
+  return window['go']['backend']['App']['GetHeadState']();
+}
+
+export function GetOperationHistory() {
+  return window['go']['backend']['App']['GetOperationHistory']();
+}
+
+export function GetOperationState() {
+  return window['go']['backend']['App']['GetOperationState']();
+}
+
 export function GetRepoRoot() {
   return window['go']['backend']['App']['GetRepoRoot']();
 }
@@ -42,14 +158,98 @@ export function InitRepo(arg1) {
   return window['go']['backend']['App']['InitRepo'](arg1);
 }
 
+export function ListSnapshots() {
+  return window['go']['backend']['App']['ListSnapshots']();
+}
+
+export function ListTags() {
+  return window['go']['backend']['App']['ListTags']();
+}
+
+export function Merge(arg1, arg2) {
+  return window['go']['backend']['App']['Merge'](arg1, arg2);
+}
+
+export function PlanRebase(arg1) {
+  return window['go']['backend']['App']['PlanRebase'](arg1);
+}
+
+export function PreviewMerge(arg1) {
+  return window['go']['backend']['App']['PreviewMerge'](arg1);
+}
+
+export function PreviewReset(arg1, arg2) {
+  return window['go']['backend']['App']['PreviewReset'](arg1, arg2);
+}
+
 export function PushChanges() {
   return window['go']['backend']['App']['PushChanges']();
 }
 
+export function PushTag(arg1, arg2) {
+  return window['go']['backend']['App']['PushTag'](arg1, arg2);
+}
+
+export function Redo() {
+  return window['go']['backend']['App']['Redo']();
+}
+
+export function ResetTo(arg1, arg2) {
+  return window['go']['backend']['App']['ResetTo'](arg1, arg2);
+}
+
+export function RestoreSnapshot(arg1) {
+  return window['go']['backend']['App']['RestoreSnapshot'](arg1);
+}
+
+export function Revert(arg1, arg2) {
+  return window['go']['backend']['App']['Revert'](arg1, arg2);
+}
+
+export function Search(arg1) {
+  return window['go']['backend']['App']['Search'](arg1);
+}
+
+export function SetBranchNamePolicy(arg1) {
+  return window['go']['backend']['App']['SetBranchNamePolicy'](arg1);
+}
+
+export function SetDiffOptions(arg1) {
+  return window['go']['backend']['App']['SetDiffOptions'](arg1);
+}
+
+export function SkipOperation() {
+  return window['go']['backend']['App']['SkipOperation']();
+}
+
+export function StageAll() {
+  return window['go']['backend']['App']['StageAll']();
+}
+
+export function StageFiles(arg1) {
+  return window['go']['backend']['App']['StageFiles'](arg1);
+}
+
 export function SwitchBranch(arg1) {
   return window['go']['backend']['App']['SwitchBranch'](arg1);
 }
 
+export function Undo() {
+  return window['go']['backend']['App']['Undo']();
+}
+
+export function UnstageAll() {
+  return window['go']['backend']['App']['UnstageAll']();
+}
+
+export function UnstageFiles(arg1) {
+  return window['go']['backend']['App']['UnstageFiles'](arg1);
+}
+
+export function ValidateBranchName(arg1) {
+  return window['go']['backend']['App']['ValidateBranchName'](arg1);
+}
+
 export function ValidateRepo(arg1) {
   return window['go']['backend']['App']['ValidateRepo'](arg1);
 }
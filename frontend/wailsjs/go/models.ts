@@ -1,5 +1,67 @@
 export namespace types {
 	
+	export class BinaryInfo {
+	    OldSize: number;
+	    NewSize: number;
+	    MimeType: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BinaryInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.OldSize = source["OldSize"];
+	        this.NewSize = source["NewSize"];
+	        this.MimeType = source["MimeType"];
+	    }
+	}
+	export class BlameLine {
+	    LineNumber: number;
+	    OriginalLine: number;
+	    OriginalPath: string;
+	    SHA: string;
+	    Author: string;
+	    AuthorEmail: string;
+	    AuthorTime: number;
+	    Summary: string;
+	    Content: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BlameLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.LineNumber = source["LineNumber"];
+	        this.OriginalLine = source["OriginalLine"];
+	        this.OriginalPath = source["OriginalPath"];
+	        this.SHA = source["SHA"];
+	        this.Author = source["Author"];
+	        this.AuthorEmail = source["AuthorEmail"];
+	        this.AuthorTime = source["AuthorTime"];
+	        this.Summary = source["Summary"];
+	        this.Content = source["Content"];
+	    }
+	}
+	export class BlameOptions {
+	    IgnoreWhitespace: boolean;
+	    DetectMoves: boolean;
+	    DetectCopies: boolean;
+	    IgnoreRevsFile: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BlameOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.IgnoreWhitespace = source["IgnoreWhitespace"];
+	        this.DetectMoves = source["DetectMoves"];
+	        this.DetectCopies = source["DetectCopies"];
+	        this.IgnoreRevsFile = source["IgnoreRevsFile"];
+	    }
+	}
 	export class Branch {
 	    Name: string;
 	    IsCurrent: boolean;
@@ -16,10 +78,145 @@ export namespace types {
 	        this.IsRemote = source["IsRemote"];
 	    }
 	}
+	export class BranchNamePolicy {
+	    Prefixes: string[];
+	    TicketPattern: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchNamePolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Prefixes = source["Prefixes"];
+	        this.TicketPattern = source["TicketPattern"];
+	    }
+	}
+	export class FileStat {
+	    Path: string;
+	    OldPath: string;
+	    Insertions: number;
+	    Deletions: number;
+	    Binary: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.OldPath = source["OldPath"];
+	        this.Insertions = source["Insertions"];
+	        this.Deletions = source["Deletions"];
+	        this.Binary = source["Binary"];
+	    }
+	}
+	export class DiffStat {
+	    Files: FileStat[];
+	    FilesChanged: number;
+	    Insertions: number;
+	    Deletions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffStat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Files = this.convertValues(source["Files"], FileStat);
+	        this.FilesChanged = source["FilesChanged"];
+	        this.Insertions = source["Insertions"];
+	        this.Deletions = source["Deletions"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ChangeStats {
+	    Staged: DiffStat;
+	    Unstaged: DiffStat;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangeStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Staged = this.convertValues(source["Staged"], DiffStat);
+	        this.Unstaged = this.convertValues(source["Unstaged"], DiffStat);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CherryPickOptions {
+	    RecordOrigin: boolean;
+	    Mainline: number;
+	    NoCommit: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CherryPickOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.RecordOrigin = source["RecordOrigin"];
+	        this.Mainline = source["Mainline"];
+	        this.NoCommit = source["NoCommit"];
+	    }
+	}
+	export class CleanResult {
+	    Paths: string[];
+	    DryRun: boolean;
+	    SnapshotID: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CleanResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Paths = source["Paths"];
+	        this.DryRun = source["DryRun"];
+	        this.SnapshotID = source["SnapshotID"];
+	    }
+	}
 	export class CommitResult {
 	    Success: boolean;
 	    CommitSHA: string;
 	    Message: string;
+	    Stats?: DiffStat;
 	
 	    static createFrom(source: any = {}) {
 	        return new CommitResult(source);
@@ -30,15 +227,135 @@ export namespace types {
 	        this.Success = source["Success"];
 	        this.CommitSHA = source["CommitSHA"];
 	        this.Message = source["Message"];
+	        this.Stats = this.convertValues(source["Stats"], DiffStat);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitSummary {
+	    SHA: string;
+	    Subject: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SHA = source["SHA"];
+	        this.Subject = source["Subject"];
+	    }
+	}
+	export class DiffSummary {
+	    FilesChanged: number;
+	    Insertions: number;
+	    Deletions: number;
+	    HunkCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.FilesChanged = source["FilesChanged"];
+	        this.Insertions = source["Insertions"];
+	        this.Deletions = source["Deletions"];
+	        this.HunkCount = source["HunkCount"];
+	    }
+	}
+	export class WordSegment {
+	    Kind: string;
+	    Text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WordSegment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.Text = source["Text"];
+	    }
+	}
+	export class LineSpan {
+	    Start: number;
+	    End: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LineSpan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Start = source["Start"];
+	        this.End = source["End"];
 	    }
 	}
+	export class DiffLine {
+	    Kind: string;
+	    OldLineNo: number;
+	    NewLineNo: number;
+	    Content: string;
+	    Changes: LineSpan[];
+	    Segments: WordSegment[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Kind = source["Kind"];
+	        this.OldLineNo = source["OldLineNo"];
+	        this.NewLineNo = source["NewLineNo"];
+	        this.Content = source["Content"];
+	        this.Changes = this.convertValues(source["Changes"], LineSpan);
+	        this.Segments = this.convertValues(source["Segments"], WordSegment);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class DiffHunk {
 	    Header: string;
 	    OldStart: number;
 	    OldLines: number;
 	    NewStart: number;
 	    NewLines: number;
-	    Lines: string[];
+	    Section: string;
+	    Lines: DiffLine[];
 	
 	    static createFrom(source: any = {}) {
 	        return new DiffHunk(source);
@@ -51,13 +368,46 @@ export namespace types {
 	        this.OldLines = source["OldLines"];
 	        this.NewStart = source["NewStart"];
 	        this.NewLines = source["NewLines"];
-	        this.Lines = source["Lines"];
+	        this.Section = source["Section"];
+	        this.Lines = this.convertValues(source["Lines"], DiffLine);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class DiffResult {
 	    FilePath: string;
+	    Mode: string;
 	    Diff: string;
 	    Hunks: DiffHunk[];
+	    Summary: DiffSummary;
+	    Truncated: boolean;
+	    IsNew: boolean;
+	    IsDeleted: boolean;
+	    OldMode: string;
+	    NewMode: string;
+	    OldBlob: string;
+	    NewBlob: string;
+	    IsBinary: boolean;
+	    Binary?: BinaryInfo;
+	    IsSymlink: boolean;
+	    OldSymlinkTarget: string;
+	    NewSymlinkTarget: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new DiffResult(source);
@@ -66,8 +416,22 @@ export namespace types {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.FilePath = source["FilePath"];
+	        this.Mode = source["Mode"];
 	        this.Diff = source["Diff"];
 	        this.Hunks = this.convertValues(source["Hunks"], DiffHunk);
+	        this.Summary = this.convertValues(source["Summary"], DiffSummary);
+	        this.Truncated = source["Truncated"];
+	        this.IsNew = source["IsNew"];
+	        this.IsDeleted = source["IsDeleted"];
+	        this.OldMode = source["OldMode"];
+	        this.NewMode = source["NewMode"];
+	        this.OldBlob = source["OldBlob"];
+	        this.NewBlob = source["NewBlob"];
+	        this.IsBinary = source["IsBinary"];
+	        this.Binary = this.convertValues(source["Binary"], BinaryInfo);
+	        this.IsSymlink = source["IsSymlink"];
+	        this.OldSymlinkTarget = source["OldSymlinkTarget"];
+	        this.NewSymlinkTarget = source["NewSymlinkTarget"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -88,36 +452,1074 @@ export namespace types {
 		    return a;
 		}
 	}
-	export class FileStatus {
+	export class ComparedFile {
 	    Path: string;
+	    OldPath: string;
 	    Status: string;
-	    Staged: boolean;
+	    Diff?: DiffResult;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileStatus(source);
+	        return new ComparedFile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.Path = source["Path"];
+	        this.OldPath = source["OldPath"];
 	        this.Status = source["Status"];
-	        this.Staged = source["Staged"];
+	        this.Diff = this.convertValues(source["Diff"], DiffResult);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class GitRepo {
-	    Path: string;
-	    CurrentBranch: string;
+	export class Comparison {
+	    Base: string;
+	    Head: string;
+	    BaseSHA: string;
+	    HeadSHA: string;
+	    MergeBase: string;
+	    Ahead: CommitSummary[];
+	    Behind: CommitSummary[];
+	    Files: ComparedFile[];
+	    Summary: DiffSummary;
+	    Stats: DiffStat;
 	
 	    static createFrom(source: any = {}) {
-	        return new GitRepo(source);
+	        return new Comparison(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.Path = source["Path"];
-	        this.CurrentBranch = source["CurrentBranch"];
+	        this.Base = source["Base"];
+	        this.Head = source["Head"];
+	        this.BaseSHA = source["BaseSHA"];
+	        this.HeadSHA = source["HeadSHA"];
+	        this.MergeBase = source["MergeBase"];
+	        this.Ahead = this.convertValues(source["Ahead"], CommitSummary);
+	        this.Behind = this.convertValues(source["Behind"], CommitSummary);
+	        this.Files = this.convertValues(source["Files"], ComparedFile);
+	        this.Summary = this.convertValues(source["Summary"], DiffSummary);
+	        this.Stats = this.convertValues(source["Stats"], DiffStat);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
+	export class ContentMatch {
+	    Path: string;
+	    LineNumber: number;
+	    Column: number;
+	    Content: string;
+	    Before: string[];
+	    After: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ContentMatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.LineNumber = source["LineNumber"];
+	        this.Column = source["Column"];
+	        this.Content = source["Content"];
+	        this.Before = source["Before"];
+	        this.After = source["After"];
+	    }
+	}
+	export class TokenSpan {
+	    Start: number;
+	    End: number;
+	    Class: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TokenSpan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Start = source["Start"];
+	        this.End = source["End"];
+	        this.Class = source["Class"];
+	    }
+	}
+	export class LineHighlight {
+	    Tokens: TokenSpan[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LineHighlight(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Tokens = this.convertValues(source["Tokens"], TokenSpan);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HunkHighlight {
+	    Lines: LineHighlight[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HunkHighlight(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Lines = this.convertValues(source["Lines"], LineHighlight);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiffHighlight {
+	    FilePath: string;
+	    Language: string;
+	    Hunks: HunkHighlight[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffHighlight(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.FilePath = source["FilePath"];
+	        this.Language = source["Language"];
+	        this.Hunks = this.convertValues(source["Hunks"], HunkHighlight);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DiffHunkPage {
+	    FilePath: string;
+	    Offset: number;
+	    TotalHunks: number;
+	    Hunks: DiffHunk[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffHunkPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.FilePath = source["FilePath"];
+	        this.Offset = source["Offset"];
+	        this.TotalHunks = source["TotalHunks"];
+	        this.Hunks = this.convertValues(source["Hunks"], DiffHunk);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DiffOptions {
+	    IgnoreAllSpace: boolean;
+	    IgnoreSpaceChange: boolean;
+	    IgnoreBlankLines: boolean;
+	    ContextLines?: number;
+	    Algorithm: string;
+	    WordDiff: boolean;
+	    FunctionContext: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.IgnoreAllSpace = source["IgnoreAllSpace"];
+	        this.IgnoreSpaceChange = source["IgnoreSpaceChange"];
+	        this.IgnoreBlankLines = source["IgnoreBlankLines"];
+	        this.ContextLines = source["ContextLines"];
+	        this.Algorithm = source["Algorithm"];
+	        this.WordDiff = source["WordDiff"];
+	        this.FunctionContext = source["FunctionContext"];
+	    }
+	}
+	
+	
+	
+	export class DiffTarget {
+	    Mode: string;
+	    From: string;
+	    To: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Mode = source["Mode"];
+	        this.From = source["From"];
+	        this.To = source["To"];
+	    }
+	}
+	export class DiscardResult {
+	    Paths: string[];
+	    SnapshotID: string;
+	    NeedsConfirmation: boolean;
+	    ConfirmToken: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiscardResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Paths = source["Paths"];
+	        this.SnapshotID = source["SnapshotID"];
+	        this.NeedsConfirmation = source["NeedsConfirmation"];
+	        this.ConfirmToken = source["ConfirmToken"];
+	    }
+	}
+	export class FileDiffs {
+	    FilePath: string;
+	    Staged?: DiffResult;
+	    Unstaged?: DiffResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileDiffs(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.FilePath = source["FilePath"];
+	        this.Staged = this.convertValues(source["Staged"], DiffResult);
+	        this.Unstaged = this.convertValues(source["Unstaged"], DiffResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FileHistoryEntry {
+	    SHA: string;
+	    Author: string;
+	    AuthorEmail: string;
+	    AuthorTime: number;
+	    Subject: string;
+	    Path: string;
+	    OldPath: string;
+	    Status: string;
+	    Diff?: DiffResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileHistoryEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SHA = source["SHA"];
+	        this.Author = source["Author"];
+	        this.AuthorEmail = source["AuthorEmail"];
+	        this.AuthorTime = source["AuthorTime"];
+	        this.Subject = source["Subject"];
+	        this.Path = source["Path"];
+	        this.OldPath = source["OldPath"];
+	        this.Status = source["Status"];
+	        this.Diff = this.convertValues(source["Diff"], DiffResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FileLines {
+	    Path: string;
+	    Revision: string;
+	    Start: number;
+	    End: number;
+	    TotalLines: number;
+	    Lines: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FileLines(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Revision = source["Revision"];
+	        this.Start = source["Start"];
+	        this.End = source["End"];
+	        this.TotalLines = source["TotalLines"];
+	        this.Lines = source["Lines"];
+	    }
+	}
+	
+	export class FileStatus {
+	    Path: string;
+	    Status: string;
+	    Staged: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.Status = source["Status"];
+	        this.Staged = source["Staged"];
+	    }
+	}
+	export class HeadState {
+	    Branch: string;
+	    Detached: boolean;
+	    DetachedAt: string;
+	    Unborn: boolean;
+	    Operation: string;
+	    Step: number;
+	    TotalSteps: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeadState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Branch = source["Branch"];
+	        this.Detached = source["Detached"];
+	        this.DetachedAt = source["DetachedAt"];
+	        this.Unborn = source["Unborn"];
+	        this.Operation = source["Operation"];
+	        this.Step = source["Step"];
+	        this.TotalSteps = source["TotalSteps"];
+	    }
+	}
+	export class GitRepo {
+	    Path: string;
+	    CurrentBranch: string;
+	    HeadState: HeadState;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitRepo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Path = source["Path"];
+	        this.CurrentBranch = source["CurrentBranch"];
+	        this.HeadState = this.convertValues(source["HeadState"], HeadState);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GraphCommit {
+	    SHA: string;
+	    Parents: string[];
+	    Author: string;
+	    AuthorTime: number;
+	    Subject: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GraphCommit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SHA = source["SHA"];
+	        this.Parents = source["Parents"];
+	        this.Author = source["Author"];
+	        this.AuthorTime = source["AuthorTime"];
+	        this.Subject = source["Subject"];
+	    }
+	}
+	export class GraphEdge {
+	    From: number;
+	    To: number;
+	    Color: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GraphEdge(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.From = source["From"];
+	        this.To = source["To"];
+	        this.Color = source["Color"];
+	    }
+	}
+	export class GraphRow {
+	    Commit: GraphCommit;
+	    Column: number;
+	    Color: number;
+	    Edges: GraphEdge[];
+	    Width: number;
+	    Merge: boolean;
+	    Fork: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GraphRow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Commit = this.convertValues(source["Commit"], GraphCommit);
+	        this.Column = source["Column"];
+	        this.Color = source["Color"];
+	        this.Edges = this.convertValues(source["Edges"], GraphEdge);
+	        this.Width = source["Width"];
+	        this.Merge = source["Merge"];
+	        this.Fork = source["Fork"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GraphPage {
+	    Offset: number;
+	    Rows: GraphRow[];
+	    HasMore: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GraphPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Offset = source["Offset"];
+	        this.Rows = this.convertValues(source["Rows"], GraphRow);
+	        this.HasMore = source["HasMore"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class RepoState {
+	    Head: string;
+	    HeadSHA: string;
+	    IndexTree: string;
+	    Refs: Record<string, string>;
+	    SnapshotID: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepoState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Head = source["Head"];
+	        this.HeadSHA = source["HeadSHA"];
+	        this.IndexTree = source["IndexTree"];
+	        this.Refs = source["Refs"];
+	        this.SnapshotID = source["SnapshotID"];
+	    }
+	}
+	export class JournalEntry {
+	    ID: number;
+	    Operation: string;
+	    Description: string;
+	    Timestamp: number;
+	    Paths: string[];
+	    Before: RepoState;
+	    After: RepoState;
+	    Undone: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new JournalEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Operation = source["Operation"];
+	        this.Description = source["Description"];
+	        this.Timestamp = source["Timestamp"];
+	        this.Paths = source["Paths"];
+	        this.Before = this.convertValues(source["Before"], RepoState);
+	        this.After = this.convertValues(source["After"], RepoState);
+	        this.Undone = source["Undone"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class LineRange {
+	    Start: number;
+	    End: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LineRange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Start = source["Start"];
+	        this.End = source["End"];
+	    }
+	}
+	
+	export class MergeOptions {
+	    FastForward: string;
+	    Squash: boolean;
+	    Message: string;
+	    StrategyOption: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.FastForward = source["FastForward"];
+	        this.Squash = source["Squash"];
+	        this.Message = source["Message"];
+	        this.StrategyOption = source["StrategyOption"];
+	    }
+	}
+	export class MergePreview {
+	    Branch: string;
+	    HeadSHA: string;
+	    BranchSHA: string;
+	    Outcome: string;
+	    Tree: string;
+	    Conflicts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MergePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Branch = source["Branch"];
+	        this.HeadSHA = source["HeadSHA"];
+	        this.BranchSHA = source["BranchSHA"];
+	        this.Outcome = source["Outcome"];
+	        this.Tree = source["Tree"];
+	        this.Conflicts = source["Conflicts"];
+	    }
+	}
+	export class MergeResult {
+	    Branch: string;
+	    Outcome: string;
+	    HeadSHA: string;
+	    Conflicts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Branch = source["Branch"];
+	        this.Outcome = source["Outcome"];
+	        this.HeadSHA = source["HeadSHA"];
+	        this.Conflicts = source["Conflicts"];
+	    }
+	}
+	export class OperationState {
+	    Operation: string;
+	    Branch: string;
+	    Step: number;
+	    TotalSteps: number;
+	    Conflicts: string[];
+	    CanSkip: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OperationState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Operation = source["Operation"];
+	        this.Branch = source["Branch"];
+	        this.Step = source["Step"];
+	        this.TotalSteps = source["TotalSteps"];
+	        this.Conflicts = source["Conflicts"];
+	        this.CanSkip = source["CanSkip"];
+	    }
+	}
+	export class PickResult {
+	    Operation: string;
+	    Completed: boolean;
+	    HeadSHA: string;
+	    StoppedAt: string;
+	    Conflicts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PickResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Operation = source["Operation"];
+	        this.Completed = source["Completed"];
+	        this.HeadSHA = source["HeadSHA"];
+	        this.StoppedAt = source["StoppedAt"];
+	        this.Conflicts = source["Conflicts"];
+	    }
+	}
+	export class RebaseStep {
+	    Action: string;
+	    SHA: string;
+	    Subject: string;
+	    Command: string;
+	    Message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RebaseStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Action = source["Action"];
+	        this.SHA = source["SHA"];
+	        this.Subject = source["Subject"];
+	        this.Command = source["Command"];
+	        this.Message = source["Message"];
+	    }
+	}
+	export class RebasePlan {
+	    Onto: string;
+	    OntoSHA: string;
+	    HeadSHA: string;
+	    Steps: RebaseStep[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RebasePlan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Onto = source["Onto"];
+	        this.OntoSHA = source["OntoSHA"];
+	        this.HeadSHA = source["HeadSHA"];
+	        this.Steps = this.convertValues(source["Steps"], RebaseStep);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RebaseResult {
+	    Completed: boolean;
+	    Stop: string;
+	    StoppedAt: string;
+	    Step: number;
+	    TotalSteps: number;
+	    Conflicts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RebaseResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Completed = source["Completed"];
+	        this.Stop = source["Stop"];
+	        this.StoppedAt = source["StoppedAt"];
+	        this.Step = source["Step"];
+	        this.TotalSteps = source["TotalSteps"];
+	        this.Conflicts = source["Conflicts"];
+	    }
+	}
+	
+	
+	export class ResetPreflight {
+	    Revision: string;
+	    Mode: string;
+	    CurrentSHA: string;
+	    TargetSHA: string;
+	    UnpushedCommits: CommitSummary[];
+	    DiscardedFiles: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ResetPreflight(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Revision = source["Revision"];
+	        this.Mode = source["Mode"];
+	        this.CurrentSHA = source["CurrentSHA"];
+	        this.TargetSHA = source["TargetSHA"];
+	        this.UnpushedCommits = this.convertValues(source["UnpushedCommits"], CommitSummary);
+	        this.DiscardedFiles = source["DiscardedFiles"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ResetResult {
+	    Mode: string;
+	    PreviousSHA: string;
+	    TargetSHA: string;
+	    RecoveryRef: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResetResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Mode = source["Mode"];
+	        this.PreviousSHA = source["PreviousSHA"];
+	        this.TargetSHA = source["TargetSHA"];
+	        this.RecoveryRef = source["RecoveryRef"];
+	    }
+	}
+	export class RevertOptions {
+	    Mainline: number;
+	    NoCommit: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RevertOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Mainline = source["Mainline"];
+	        this.NoCommit = source["NoCommit"];
+	    }
+	}
+	export class SearchCommit {
+	    SHA: string;
+	    Author: string;
+	    AuthorEmail: string;
+	    AuthorTime: number;
+	    Subject: string;
+	    Files: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchCommit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.SHA = source["SHA"];
+	        this.Author = source["Author"];
+	        this.AuthorEmail = source["AuthorEmail"];
+	        this.AuthorTime = source["AuthorTime"];
+	        this.Subject = source["Subject"];
+	        this.Files = source["Files"];
+	    }
+	}
+	export class SearchQuery {
+	    Mode: string;
+	    Pattern: string;
+	    Revision: string;
+	    Paths: string[];
+	    IgnoreCase: boolean;
+	    Fixed: boolean;
+	    Context: number;
+	    Limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchQuery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Mode = source["Mode"];
+	        this.Pattern = source["Pattern"];
+	        this.Revision = source["Revision"];
+	        this.Paths = source["Paths"];
+	        this.IgnoreCase = source["IgnoreCase"];
+	        this.Fixed = source["Fixed"];
+	        this.Context = source["Context"];
+	        this.Limit = source["Limit"];
+	    }
+	}
+	export class SearchResult {
+	    Mode: string;
+	    Commits: SearchCommit[];
+	    Matches: ContentMatch[];
+	    Truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Mode = source["Mode"];
+	        this.Commits = this.convertValues(source["Commits"], SearchCommit);
+	        this.Matches = this.convertValues(source["Matches"], ContentMatch);
+	        this.Truncated = source["Truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Snapshot {
+	    ID: string;
+	    Reason: string;
+	    CreatedAt: number;
+	    Paths: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Snapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ID = source["ID"];
+	        this.Reason = source["Reason"];
+	        this.CreatedAt = source["CreatedAt"];
+	        this.Paths = source["Paths"];
+	    }
+	}
+	export class Tag {
+	    Name: string;
+	    Target: string;
+	    Annotated: boolean;
+	    Signed: boolean;
+	    Tagger: string;
+	    TaggerEmail: string;
+	    Date: number;
+	    Message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Tag(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Name = source["Name"];
+	        this.Target = source["Target"];
+	        this.Annotated = source["Annotated"];
+	        this.Signed = source["Signed"];
+	        this.Tagger = source["Tagger"];
+	        this.TaggerEmail = source["TaggerEmail"];
+	        this.Date = source["Date"];
+	        this.Message = source["Message"];
+	    }
+	}
+	
 
 }
 
@@ -3,14 +3,26 @@ package main
 import (
 	"embed"
 	"flag"
+	"fmt"
+	"os"
 
 	"git-gui/backend"
+	"git-gui/backend/rebase"
 )
 
 //go:embed all:frontend/dist
 var assets embed.FS
 
 func main() {
+	// git runs the app as its editor while an interactive rebase is driven
+	if len(os.Args) > 1 && os.Args[1] == rebase.HelperFlag {
+		if err := rebase.RunEditor(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var projectPath string
 	flag.StringVar(&projectPath, "project", "", "path to git project")
 	flag.StringVar(&projectPath, "p", "", "path to git project (shorthand)")
@@ -2,6 +2,8 @@ package backend_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"git-gui/backend"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockGitExecutor implements git.GitExecutor for testing.
@@ -22,10 +25,26 @@ func (m *MockGitExecutor) Execute(args ...string) (string, error) {
 	return callArgs.String(0), callArgs.Error(1)
 }
 
+func (m *MockGitExecutor) ExecuteWithInput(input string, args ...string) (string, error) {
+	callArgs := m.Called(input, args)
+	return callArgs.String(0), callArgs.Error(1)
+}
+
+func (m *MockGitExecutor) ExecuteWithEnv(env []string, args ...string) (string, error) {
+	callArgs := m.Called(env, args)
+	return callArgs.String(0), callArgs.Error(1)
+}
+
 func newTestApp(executor git.GitExecutor) *backend.App {
 	return backend.NewTestApp(executor, &types.GitRepo{Path: "/test/repo", CurrentBranch: "main"})
 }
 
+// intPtr returns a pointer to n, for optional settings such as
+// DiffOptions.ContextLines.
+func intPtr(n int) *int {
+	return &n
+}
+
 func TestGetGitStatus_Success(t *testing.T) {
 	mockExec := new(MockGitExecutor)
 	mockExec.On("Execute", []string{"status", "--porcelain"}).
@@ -133,6 +152,7 @@ func TestSwitchBranch_Success(t *testing.T) {
 	mockExec := new(MockGitExecutor)
 	mockExec.On("Execute", []string{"checkout", "develop"}).
 		Return("Switched to branch 'develop'\n", nil)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/develop\n"})
 
 	app := newTestApp(mockExec)
 	err := app.SwitchBranch("develop")
@@ -140,6 +160,24 @@ func TestSwitchBranch_Success(t *testing.T) {
 	assert.NoError(t, err)
 	repo, _ := app.GetCurrentRepo()
 	assert.Equal(t, "develop", repo.CurrentBranch)
+	assert.Equal(t, "develop", repo.HeadState.Branch)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSwitchBranch_TagDetachesHead(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"checkout", "v1.0"}).
+		Return("HEAD is now at abc1234 release\n", nil)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "abc1234def5678abc1234def5678abc1234def56\n"})
+
+	app := newTestApp(mockExec)
+	err := app.SwitchBranch("v1.0")
+
+	assert.NoError(t, err)
+	repo, _ := app.GetCurrentRepo()
+	assert.Equal(t, "", repo.CurrentBranch)
+	assert.True(t, repo.HeadState.Detached)
+	assert.Equal(t, "abc1234", repo.HeadState.DetachedAt)
 	mockExec.AssertExpectations(t)
 }
 
@@ -157,8 +195,11 @@ func TestSwitchBranch_Error(t *testing.T) {
 
 func TestCreateBranch_Success(t *testing.T) {
 	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "feature/new"}).
+		Return("feature/new\n", nil)
 	mockExec.On("Execute", []string{"checkout", "-b", "feature/new"}).
 		Return("Switched to a new branch 'feature/new'\n", nil)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/feature/new\n"})
 
 	app := newTestApp(mockExec)
 	err := app.CreateBranch("feature/new")
@@ -169,12 +210,29 @@ func TestCreateBranch_Success(t *testing.T) {
 	mockExec.AssertExpectations(t)
 }
 
+// expectCommitStats answers the line count lookup made after every commit.
+func expectCommitStats(mockExec *MockGitExecutor, output string) {
+	mockExec.On("Execute", []string{"show", "--numstat", "-z", "-M", "--format=", "HEAD"}).Return(output, nil)
+}
+
+// expectPartialCommit answers the HEAD state and staged rename lookups that
+// CommitFiles makes before committing only the selected files.
+func expectPartialCommit(t *testing.T, mockExec *MockGitExecutor, renames string) {
+	newGitDir(t, mockExec, map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+	})
+	mockExec.On("Execute", []string{"diff", "--cached", "--name-status", "-z", "-M"}).Return(renames, nil)
+}
+
 func TestCommitFiles_Success(t *testing.T) {
 	mockExec := new(MockGitExecutor)
-	mockExec.On("Execute", []string{"add", "file1.txt", "file2.txt"}).
+	mockExec.On("Execute", []string{"add", "--", "file1.txt", "file2.txt"}).
 		Return("", nil)
-	mockExec.On("Execute", []string{"commit", "-m", "test commit"}).
+	expectPartialCommit(t, mockExec, "")
+	mockExec.On("Execute", []string{"commit", "--only", "-m", "test commit", "--", "file1.txt", "file2.txt"}).
 		Return("[main abc1234] test commit\n 2 files changed\n", nil)
+	expectCommitStats(mockExec, "3\t1\tfile1.txt\x00-\t-\tfile2.txt\x00")
 
 	app := newTestApp(mockExec)
 	result, err := app.CommitFiles([]string{"file1.txt", "file2.txt"}, "test commit")
@@ -183,6 +241,60 @@ func TestCommitFiles_Success(t *testing.T) {
 	assert.True(t, result.Success)
 	assert.Equal(t, "abc1234", result.CommitSHA)
 	assert.Equal(t, "test commit", result.Message)
+	assert.Equal(t, 2, result.Stats.FilesChanged)
+	assert.Equal(t, 3, result.Stats.Insertions)
+	assert.True(t, result.Stats.Files[1].Binary)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCommitFiles_OnlySelectedDeletion(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"add", "--", "removed.txt"}).
+		Return("", nil)
+	expectPartialCommit(t, mockExec, "")
+	mockExec.On("Execute", []string{"commit", "--only", "-m", "remove file", "--", "removed.txt"}).
+		Return("[main 9f8e7d6] remove file\n 1 file changed, 3 deletions(-)\n", nil)
+	expectCommitStats(mockExec, "0\t3\tremoved.txt\x00")
+
+	app := newTestApp(mockExec)
+	result, err := app.CommitFiles([]string{"removed.txt"}, "remove file")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "9f8e7d6", result.CommitSHA)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCommitFiles_IncludesRenameSource(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"add", "--", "new.txt"}).Return("", nil)
+	expectPartialCommit(t, mockExec, "R100\x00old.txt\x00new.txt\x00M\x00other.txt\x00")
+	mockExec.On("Execute", []string{"commit", "--only", "-m", "rename", "--", "new.txt", "old.txt"}).
+		Return("[main abc1234] rename\n", nil)
+	expectCommitStats(mockExec, "")
+
+	app := newTestApp(mockExec)
+	_, err := app.CommitFiles([]string{"new.txt"}, "rename")
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCommitFiles_DuringMergeCommitsIndex(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"add", "--", "resolved.txt"}).Return("", nil)
+	newGitDir(t, mockExec, map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+		"MERGE_HEAD":      "89abcdef0123456789abcdef0123456789abcdef\n",
+	})
+	mockExec.On("Execute", []string{"commit", "-m", "merge"}).Return("[main abc1234] merge\n", nil)
+	expectCommitStats(mockExec, "")
+
+	app := newTestApp(mockExec)
+	result, err := app.CommitFiles([]string{"resolved.txt"}, "merge")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abc1234", result.CommitSHA)
 	mockExec.AssertExpectations(t)
 }
 
@@ -204,7 +316,7 @@ func TestCommitFiles_EmptyMessage(t *testing.T) {
 
 func TestCommitFiles_StageError(t *testing.T) {
 	mockExec := new(MockGitExecutor)
-	mockExec.On("Execute", []string{"add", "bad.txt"}).
+	mockExec.On("Execute", []string{"add", "--", "bad.txt"}).
 		Return("", errors.New("path not found"))
 
 	app := newTestApp(mockExec)
@@ -240,10 +352,12 @@ func TestPushChanges_Error(t *testing.T) {
 
 func TestCommitAndPush_Success(t *testing.T) {
 	mockExec := new(MockGitExecutor)
-	mockExec.On("Execute", []string{"add", "file.txt"}).
+	mockExec.On("Execute", []string{"add", "--", "file.txt"}).
 		Return("", nil)
-	mockExec.On("Execute", []string{"commit", "-m", "push me"}).
+	expectPartialCommit(t, mockExec, "")
+	mockExec.On("Execute", []string{"commit", "--only", "-m", "push me", "--", "file.txt"}).
 		Return("[main def5678] push me\n", nil)
+	expectCommitStats(mockExec, "")
 	mockExec.On("Execute", []string{"push"}).
 		Return("", nil)
 
@@ -257,10 +371,12 @@ func TestCommitAndPush_Success(t *testing.T) {
 
 func TestCommitAndPush_PushFails(t *testing.T) {
 	mockExec := new(MockGitExecutor)
-	mockExec.On("Execute", []string{"add", "file.txt"}).
+	mockExec.On("Execute", []string{"add", "--", "file.txt"}).
 		Return("", nil)
-	mockExec.On("Execute", []string{"commit", "-m", "msg"}).
+	expectPartialCommit(t, mockExec, "")
+	mockExec.On("Execute", []string{"commit", "--only", "-m", "msg", "--", "file.txt"}).
 		Return("[main abc1234] msg\n", nil)
+	expectCommitStats(mockExec, "")
 	mockExec.On("Execute", []string{"push"}).
 		Return("", errors.New("remote rejected"))
 
@@ -303,3 +419,87 @@ func TestGetRepoRoot_NoRepo(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no repository initialized")
 }
+
+func TestGetHeadState_DetachedClearsCurrentBranch(t *testing.T) {
+	gitDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("abc1234def5678abc1234def5678abc1234def56\n"), 0o644))
+
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"rev-parse", "--absolute-git-dir"}).
+		Return(gitDir+"\n", nil)
+
+	app := newTestApp(mockExec)
+	state, err := app.GetHeadState()
+
+	assert.NoError(t, err)
+	assert.True(t, state.Detached)
+	assert.Equal(t, "abc1234", state.DetachedAt)
+	repo, _ := app.GetCurrentRepo()
+	assert.Equal(t, "", repo.CurrentBranch)
+	assert.True(t, repo.HeadState.Detached)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetHeadState_NoRepo(t *testing.T) {
+	app := backend.NewApp("")
+	_, err := app.GetHeadState()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no repository initialized")
+}
+
+func TestGetGitDiff_UntrackedFile(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "new.txt"}).Return("", nil)
+	mockExec.On("Execute", []string{"diff", "--cached", "new.txt"}).Return("", nil)
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "--", "new.txt"}).
+		Return("new.txt\n", nil)
+	mockExec.On("Execute", []string{"diff", "--no-index", "--", "/dev/null", "new.txt"}).
+		Return("", &git.GitError{
+			Args:     []string{"diff", "--no-index", "--", "/dev/null", "new.txt"},
+			Output:   "diff --git a/new.txt b/new.txt\nnew file mode 100644\nindex 0000000..3e75765\n--- /dev/null\n+++ b/new.txt\n@@ -0,0 +1 @@\n+new\n",
+			ExitCode: 1,
+		})
+
+	app := newTestApp(mockExec)
+	result, err := app.GetGitDiff("new.txt")
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsNew)
+	assert.Len(t, result.Hunks, 1)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetGitDiff_UntrackedDiffFails(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "new.txt"}).Return("", nil)
+	mockExec.On("Execute", []string{"diff", "--cached", "new.txt"}).Return("", nil)
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "--", "new.txt"}).
+		Return("new.txt\n", nil)
+	mockExec.On("Execute", []string{"diff", "--no-index", "--", "/dev/null", "new.txt"}).
+		Return("", &git.GitError{Output: "error: could not access 'new.txt'", ExitCode: 128})
+
+	app := newTestApp(mockExec)
+	_, err := app.GetGitDiff("new.txt")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get diff for untracked file")
+}
+
+func TestGetGitDiff_BinaryMetadata(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "logo.png"}).
+		Return("diff --git a/logo.png b/logo.png\nindex 8352675..eaf36c1 100644\nBinary files a/logo.png and b/logo.png differ\n", nil)
+	mockExec.On("Execute", []string{"cat-file", "-s", "8352675"}).Return("1024\n", nil)
+	mockExec.On("Execute", []string{"cat-file", "-s", "eaf36c1"}).Return("2048\n", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.GetGitDiff("logo.png")
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsBinary)
+	assert.Equal(t, int64(1024), result.Binary.OldSize)
+	assert.Equal(t, int64(2048), result.Binary.NewSize)
+	assert.Equal(t, "image/png", result.Binary.MimeType)
+	mockExec.AssertExpectations(t)
+}
@@ -0,0 +1,83 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const blameOutput = shaOne + " 1 1 1\n" +
+	"author Jane\n" +
+	"author-mail <jane@example.com>\n" +
+	"author-time 1700000000\n" +
+	"summary Add a\n" +
+	"filename a.txt\n" +
+	"\tfirst\n"
+
+func TestGetBlame(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"blame", "--porcelain", "--", "a.txt"}).Return(blameOutput, nil)
+
+	app := newTestApp(mockExec)
+	lines, err := app.GetBlame("a.txt", "", types.LineRange{}, types.BlameOptions{})
+
+	assert.NoError(t, err)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, shaOne, lines[0].SHA)
+	assert.Equal(t, "Jane", lines[0].Author)
+	assert.Equal(t, "first", lines[0].Content)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetBlame_Options(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"blame", "--porcelain", "-w", "-M", "-C",
+		"--ignore-revs-file", ".git-blame-ignore-revs", "-L", "10,20", "main", "--", "a.txt"}).Return(blameOutput, nil)
+
+	app := newTestApp(mockExec)
+	_, err := app.GetBlame("a.txt", "main", types.LineRange{Start: 10, End: 20}, types.BlameOptions{
+		IgnoreWhitespace: true,
+		DetectMoves:      true,
+		DetectCopies:     true,
+		IgnoreRevsFile:   ".git-blame-ignore-revs",
+	})
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetBlame_OpenEndedRange(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"blame", "--porcelain", "-L", "5,", "--", "a.txt"}).Return(blameOutput, nil)
+
+	app := newTestApp(mockExec)
+	_, err := app.GetBlame("a.txt", "", types.LineRange{Start: 5}, types.BlameOptions{})
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetBlame_InvalidRange(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	_, err := app.GetBlame("a.txt", "", types.LineRange{Start: 5, End: 2}, types.BlameOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid line range")
+
+	_, err = app.GetBlame("a.txt", "", types.LineRange{End: 2}, types.BlameOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid line range")
+}
+
+func TestGetBlame_GitError(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"blame", "--porcelain", "--", "missing.txt"}).Return("", assert.AnError)
+
+	app := newTestApp(mockExec)
+	_, err := app.GetBlame("missing.txt", "", types.LineRange{}, types.BlameOptions{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to blame missing.txt")
+}
@@ -0,0 +1,102 @@
+package backend_test
+
+import (
+	"errors"
+	"testing"
+
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateBranchFrom_StartPointWithoutCheckout(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "hotfix/login"}).
+		Return("hotfix/login\n", nil)
+	mockExec.On("Execute", []string{"branch", "hotfix/login", "--track", "origin/release"}).
+		Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.CreateBranchFrom("hotfix/login", "origin/release", false, true)
+
+	assert.NoError(t, err)
+	repo, _ := app.GetCurrentRepo()
+	assert.Equal(t, "main", repo.CurrentBranch)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCreateBranchFrom_CheckoutUpdatesCurrentBranch(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "feature/x"}).
+		Return("feature/x\n", nil)
+	mockExec.On("Execute", []string{"checkout", "-b", "feature/x", "develop"}).
+		Return("Switched to a new branch 'feature/x'\n", nil)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/feature/x\n"})
+
+	app := newTestApp(mockExec)
+	err := app.CreateBranchFrom("feature/x", "develop", true, false)
+
+	assert.NoError(t, err)
+	repo, _ := app.GetCurrentRepo()
+	assert.Equal(t, "feature/x", repo.CurrentBranch)
+	mockExec.AssertExpectations(t)
+}
+
+func TestValidateBranchName_RejectedByGit(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "my branch"}).
+		Return("", errors.New("fatal: 'my branch' is not a valid branch name"))
+
+	app := newTestApp(mockExec)
+	err := app.ValidateBranchName("my branch")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid branch name")
+}
+
+func TestValidateBranchName_Policy(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "feature/ABC-12-login"}).
+		Return("feature/ABC-12-login\n", nil)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "login"}).
+		Return("login\n", nil)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "bugfix/login"}).
+		Return("bugfix/login\n", nil)
+
+	app := newTestApp(mockExec)
+	err := app.SetBranchNamePolicy(types.BranchNamePolicy{
+		Prefixes:      []string{"feature/", "bugfix/"},
+		TicketPattern: `[A-Z]+-[0-9]+`,
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, app.ValidateBranchName("feature/ABC-12-login"))
+
+	err = app.ValidateBranchName("login")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must start with one of")
+
+	err = app.ValidateBranchName("bugfix/login")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must match ticket pattern")
+}
+
+func TestSetBranchNamePolicy_InvalidPattern(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+	err := app.SetBranchNamePolicy(types.BranchNamePolicy{TicketPattern: "[A-Z"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid ticket pattern")
+}
+
+func TestCreateBranchFrom_InvalidNameSkipsCreate(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "bad..name"}).
+		Return("", errors.New("fatal: not a valid branch name"))
+
+	app := newTestApp(mockExec)
+	err := app.CreateBranchFrom("bad..name", "", true, false)
+
+	assert.Error(t, err)
+	mockExec.AssertNotCalled(t, "Execute", []string{"checkout", "-b", "bad..name"})
+}
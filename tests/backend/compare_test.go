@@ -0,0 +1,90 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mergeBaseSHA = "4444444444444444444444444444444444444444"
+
+func expectCommits(mockExec *MockGitExecutor, base, head string) {
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "main^{commit}"}).Return(base+"\n", nil)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "feature^{commit}"}).Return(head+"\n", nil)
+}
+
+func TestCompare(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectCommits(mockExec, shaOne, shaTwo)
+	mockExec.On("Execute", []string{"merge-base", shaOne, shaTwo}).Return(mergeBaseSHA+"\n", nil)
+	mockExec.On("Execute", []string{"log", git.CommitSummaryFormat, shaOne + ".." + shaTwo}).Return(shaTwo+"\x00Add b\n", nil)
+	mockExec.On("Execute", []string{"log", git.CommitSummaryFormat, shaTwo + ".." + shaOne}).Return("", nil)
+	mockExec.On("Execute", []string{"diff", "--name-status", "-z", "-M", mergeBaseSHA, shaTwo}).
+		Return("M\x00a.txt\x00A\x00b.txt\x00", nil)
+	mockExec.On("Execute", []string{"diff", "-U5", "-M", mergeBaseSHA, shaTwo}).Return(
+		"diff --git a/a.txt b/a.txt\nindex 1111111..2222222 100644\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-one\n+two\n"+
+			"diff --git a/b.txt b/b.txt\nnew file mode 100644\nindex 0000000..3333333\n--- /dev/null\n+++ b/b.txt\n@@ -0,0 +1,2 @@\n+b\n+c\n", nil)
+	mockExec.On("Execute", []string{"diff", "--numstat", "-z", "-M", mergeBaseSHA, shaTwo}).
+		Return("1\t1\ta.txt\x002\t0\tb.txt\x00", nil)
+
+	app := newTestApp(mockExec)
+	assert.NoError(t, app.SetDiffOptions(types.DiffOptions{IgnoreAllSpace: true, ContextLines: intPtr(5)}))
+	comparison, err := app.Compare("main", "feature")
+
+	assert.NoError(t, err)
+	assert.Equal(t, mergeBaseSHA, comparison.MergeBase)
+	assert.Equal(t, []types.CommitSummary{{SHA: shaTwo, Subject: "Add b"}}, comparison.Ahead)
+	assert.Empty(t, comparison.Behind)
+	assert.Len(t, comparison.Files, 2)
+	assert.Equal(t, "a.txt", comparison.Files[0].Path)
+	assert.Len(t, comparison.Files[0].Diff.Hunks, 1)
+	assert.Equal(t, types.StatusAdded, comparison.Files[1].Status)
+	assert.True(t, comparison.Files[1].Diff.IsNew)
+	assert.Equal(t, types.DiffSummary{FilesChanged: 2, Insertions: 3, Deletions: 1, HunkCount: 2}, comparison.Summary)
+	assert.Equal(t, 2, comparison.Stats.FilesChanged)
+	assert.Equal(t, types.FileStat{Path: "b.txt", Insertions: 2}, comparison.Stats.Files[1])
+	mockExec.AssertExpectations(t)
+}
+
+func TestCompare_NoCommonHistory(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectCommits(mockExec, shaOne, shaTwo)
+	mockExec.On("Execute", []string{"merge-base", shaOne, shaTwo}).Return("", &git.GitError{ExitCode: 1})
+
+	app := newTestApp(mockExec)
+	_, err := app.Compare("main", "feature")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no common history")
+}
+
+func TestCompare_MismatchedPatch(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectCommits(mockExec, shaOne, shaTwo)
+	mockExec.On("Execute", []string{"merge-base", shaOne, shaTwo}).Return(mergeBaseSHA+"\n", nil)
+	mockExec.On("Execute", []string{"log", git.CommitSummaryFormat, shaOne + ".." + shaTwo}).Return("", nil)
+	mockExec.On("Execute", []string{"log", git.CommitSummaryFormat, shaTwo + ".." + shaOne}).Return("", nil)
+	mockExec.On("Execute", []string{"diff", "--name-status", "-z", "-M", mergeBaseSHA, shaTwo}).Return("M\x00a.txt\x00", nil)
+	mockExec.On("Execute", []string{"diff", "-M", mergeBaseSHA, shaTwo}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	_, err := app.Compare("main", "feature")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "diff lists 1 files but has 0 patches")
+}
+
+func TestCompare_InvalidRevisions(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	_, err := app.Compare("", "feature")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "both revisions are required")
+
+	_, err = app.Compare("main", "--all")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid revision")
+}
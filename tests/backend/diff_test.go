@@ -0,0 +1,202 @@
+package backend_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const stagedHunk = "diff --git a/file.txt b/file.txt\n--- a/file.txt\n+++ b/file.txt\n@@ -1,2 +1,3 @@\n line1\n+staged\n line2\n"
+const unstagedHunk = "diff --git a/file.txt b/file.txt\n--- a/file.txt\n+++ b/file.txt\n@@ -1,3 +1,4 @@\n line1\n staged\n+unstaged\n line2\n"
+
+func TestGetDiff_Modes(t *testing.T) {
+	tests := []struct {
+		target types.DiffTarget
+		args   []string
+	}{
+		{types.DiffTarget{Mode: types.DiffIndexToHead}, []string{"diff", "--cached", "--", "file.txt"}},
+		{types.DiffTarget{Mode: types.DiffWorktreeToHead}, []string{"diff", "HEAD", "--", "file.txt"}},
+		{types.DiffTarget{Mode: types.DiffRevisions, From: "v1.0", To: "v2.0"}, []string{"diff", "v1.0", "v2.0", "--", "file.txt"}},
+	}
+
+	for _, tt := range tests {
+		mockExec := new(MockGitExecutor)
+		mockExec.On("Execute", tt.args).Return(stagedHunk, nil)
+
+		app := newTestApp(mockExec)
+		result, err := app.GetDiff("file.txt", tt.target)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.target.Mode, result.Mode)
+		assert.Len(t, result.Hunks, 1)
+		mockExec.AssertExpectations(t)
+	}
+}
+
+func TestGetDiff_IndexModeIgnoresUntracked(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "new.txt"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.GetDiff("new.txt", types.DiffTarget{Mode: types.DiffIndexToHead})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Hunks)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetDiff_InvalidTarget(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	_, err := app.GetDiff("file.txt", types.DiffTarget{Mode: types.DiffRevisions})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a starting revision")
+
+	_, err = app.GetDiff("file.txt", types.DiffTarget{Mode: types.DiffRevisions, From: "--output=/tmp/x"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid revision")
+
+	_, err = app.GetDiff("file.txt", types.DiffTarget{Mode: "sideways"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown diff mode")
+}
+
+func TestGetFileDiffs_PartiallyStaged(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "file.txt"}).Return(stagedHunk, nil)
+	mockExec.On("Execute", []string{"diff", "--", "file.txt"}).Return(unstagedHunk, nil)
+
+	app := newTestApp(mockExec)
+	diffs, err := app.GetFileDiffs("file.txt")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "file.txt", diffs.FilePath)
+	assert.Equal(t, types.DiffIndexToHead, diffs.Staged.Mode)
+	assert.Equal(t, "staged", diffs.Staged.Hunks[0].Lines[1].Content)
+	assert.Equal(t, types.DiffWorktreeToIndex, diffs.Unstaged.Mode)
+	assert.Equal(t, "unstaged", diffs.Unstaged.Hunks[0].Lines[2].Content)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSetDiffOptions_AppliedToAllDiffs(t *testing.T) {
+	flags := []string{"-w", "-b", "--ignore-blank-lines", "-U10", "--diff-algorithm=histogram", "--word-diff=porcelain", "--function-context"}
+	withFlags := func(args ...string) []string {
+		return append(append([]string{"diff"}, flags...), args...)
+	}
+
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", withFlags("file.txt")).Return(stagedHunk, nil)
+	mockExec.On("Execute", withFlags("--cached", "--", "file.txt")).Return(stagedHunk, nil)
+
+	app := newTestApp(mockExec)
+	err := app.SetDiffOptions(types.DiffOptions{
+		IgnoreAllSpace:    true,
+		IgnoreSpaceChange: true,
+		IgnoreBlankLines:  true,
+		ContextLines:      intPtr(10),
+		Algorithm:         types.AlgorithmHistogram,
+		WordDiff:          true,
+		FunctionContext:   true,
+	})
+	assert.NoError(t, err)
+
+	_, err = app.GetGitDiff("file.txt")
+	assert.NoError(t, err)
+	_, err = app.GetDiff("file.txt", types.DiffTarget{Mode: types.DiffIndexToHead})
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSetDiffOptions_UnknownAlgorithm(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+	err := app.SetDiffOptions(types.DiffOptions{Algorithm: "quantum"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown diff algorithm")
+	assert.Equal(t, types.DiffOptions{}, app.GetDiffOptions())
+}
+
+func TestSetDiffOptions_ZeroContextLines(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "-U0", "--cached", "--", "file.txt"}).Return(stagedHunk, nil)
+
+	app := newTestApp(mockExec)
+	assert.NoError(t, app.SetDiffOptions(types.DiffOptions{ContextLines: intPtr(0)}))
+
+	_, err := app.GetDiff("file.txt", types.DiffTarget{Mode: types.DiffIndexToHead})
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSetDiffOptions_NegativeContextLines(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+	err := app.SetDiffOptions(types.DiffOptions{ContextLines: intPtr(-1)})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid context line count")
+	assert.Equal(t, types.DiffOptions{}, app.GetDiffOptions())
+}
+
+// manyHunks builds a diff of file.txt with n single-line hunks.
+func manyHunks(n int) string {
+	var b strings.Builder
+	b.WriteString("diff --git a/file.txt b/file.txt\n--- a/file.txt\n+++ b/file.txt\n")
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "@@ -%d +%d @@\n-old %d\n+new %d\n", i*10, i*10, i, i)
+	}
+	return b.String()
+}
+
+func TestGetDiff_LargeDiffIsTruncated(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "file.txt"}).Return(manyHunks(500), nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.GetDiff("file.txt", types.DiffTarget{Mode: types.DiffIndexToHead})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Empty(t, result.Hunks)
+	assert.Empty(t, result.Diff)
+	assert.Equal(t, types.DiffSummary{FilesChanged: 1, Insertions: 500, Deletions: 500, HunkCount: 500}, result.Summary)
+}
+
+func TestGetDiff_DropsRawDiff(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "file.txt"}).Return(stagedHunk, nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.GetDiff("file.txt", types.DiffTarget{Mode: types.DiffIndexToHead})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Truncated)
+	assert.Empty(t, result.Diff)
+	assert.Len(t, result.Hunks, 1)
+	assert.Equal(t, 1, result.Summary.Insertions)
+}
+
+func TestGetDiffHunks_Paging(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "file.txt"}).Return(manyHunks(500), nil)
+
+	app := newTestApp(mockExec)
+	page, err := app.GetDiffHunks("file.txt", types.DiffTarget{Mode: types.DiffIndexToHead}, 490, 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 500, page.TotalHunks)
+	assert.Equal(t, 490, page.Offset)
+	assert.Len(t, page.Hunks, 10)
+	assert.Equal(t, 4910, page.Hunks[0].OldStart)
+}
+
+func TestGetDiffHunks_InvalidWindow(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+	_, err := app.GetDiffHunks("file.txt", types.DiffTarget{Mode: types.DiffIndexToHead}, -1, 10)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid hunk window")
+}
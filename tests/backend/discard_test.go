@@ -0,0 +1,316 @@
+package backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git-gui/backend"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const discardDiff = `diff --git a/a.txt b/a.txt
+index 1111111..2222222 100644
+--- a/a.txt
++++ b/a.txt
+@@ -1,2 +1,2 @@
+-one
++ONE
+ two
+@@ -9,2 +9,3 @@
+ nine
+ ten
++eleven
+`
+
+// newDiscardApp returns an app rooted in a temporary directory holding the
+// given files.
+func newDiscardApp(t *testing.T, mockExec *MockGitExecutor, files map[string]string) *backend.App {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return backend.NewTestApp(mockExec, &types.GitRepo{Path: root})
+}
+
+// expectSnapshot mocks the commands that snapshot the given file contents.
+func expectSnapshot(mockExec *MockGitExecutor, reason string, paths, contents []string) {
+	var tree, message strings.Builder
+	message.WriteString(reason + "\n")
+	for i, content := range contents {
+		blob := strings.Repeat(string(rune('a'+i)), 40)
+		mockExec.On("ExecuteWithInput", content, []string{"hash-object", "-w", "--stdin"}).Return(blob+"\n", nil)
+		tree.WriteString("100644 blob " + blob + "\t" + string(rune('0'+i)) + "\n")
+		message.WriteString("\n" + paths[i])
+	}
+	mockExec.On("ExecuteWithInput", strings.Join(paths, "\x00")+"\x00", []string{"hash-object", "-w", "--stdin"}).
+		Return("pathsblob\n", nil)
+	tree.WriteString("100644 blob pathsblob\tpaths\n")
+	mockExec.On("ExecuteWithInput", tree.String(), []string{"mktree"}).Return("tree1\n", nil)
+	mockExec.On("Execute", []string{"-c", "user.name=git-gui", "-c", "user.email=git-gui@localhost",
+		"commit-tree", "tree1", "-m", message.String()}).Return("commit1\n", nil)
+	mockExec.On("Execute", mock.MatchedBy(func(args []string) bool {
+		return len(args) == 3 && args[0] == "update-ref" && strings.HasPrefix(args[1], "refs/gitgui/snapshots/") && args[2] == "commit1"
+	})).Return("", nil)
+}
+
+func TestDiscardFile_Tracked(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"a.txt": "changed\n"})
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "-z", "--", "a.txt"}).Return("", nil)
+	expectSnapshot(mockExec, "discard a.txt", []string{"a.txt"}, []string{"changed\n"})
+	mockExec.On("Execute", []string{"checkout", "--", "a.txt"}).Return("", nil)
+
+	result, err := app.DiscardFile("a.txt", "")
+
+	assert.NoError(t, err)
+	assert.False(t, result.NeedsConfirmation)
+	assert.NotEmpty(t, result.SnapshotID)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDiscardFile_UntrackedNeedsConfirmation(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"new.txt": "draft\n"})
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "-z", "--", "new.txt"}).Return("new.txt\x00", nil)
+	mockExec.On("Execute", []string{"hash-object", "--", "new.txt"}).Return("abc123\n", nil)
+
+	result, err := app.DiscardFile("new.txt", "")
+
+	assert.NoError(t, err)
+	assert.True(t, result.NeedsConfirmation)
+	assert.Equal(t, "abc123", result.ConfirmToken)
+	assert.Empty(t, result.SnapshotID)
+	mockExec.AssertNotCalled(t, "Execute", []string{"clean", "-f", "--", "new.txt"})
+}
+
+func TestDiscardFile_UntrackedConfirmed(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"new.txt": "draft\n"})
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "-z", "--", "new.txt"}).Return("new.txt\x00", nil)
+	mockExec.On("Execute", []string{"hash-object", "--", "new.txt"}).Return("abc123\n", nil)
+	expectSnapshot(mockExec, "discard new.txt", []string{"new.txt"}, []string{"draft\n"})
+	mockExec.On("Execute", []string{"clean", "-f", "--", "new.txt"}).Return("Removing new.txt\n", nil)
+
+	result, err := app.DiscardFile("new.txt", "abc123")
+
+	assert.NoError(t, err)
+	assert.False(t, result.NeedsConfirmation)
+	assert.NotEmpty(t, result.SnapshotID)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDiscardFile_UntrackedDirectory(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"dir/a.txt": "a\n", "dir/sub/b.txt": "b\n"})
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "-z", "--", "dir/"}).
+		Return("dir/a.txt\x00dir/sub/b.txt\x00", nil)
+	mockExec.On("Execute", []string{"hash-object", "--", "dir/a.txt", "dir/sub/b.txt"}).Return("abc123\ndef456\n", nil)
+
+	result, err := app.DiscardFile("dir/", "")
+	require.NoError(t, err)
+	assert.True(t, result.NeedsConfirmation)
+	assert.Len(t, result.ConfirmToken, 40)
+
+	expectSnapshot(mockExec, "discard dir/", []string{"dir/a.txt", "dir/sub/b.txt"}, []string{"a\n", "b\n"})
+	mockExec.On("Execute", []string{"clean", "-f", "--", "dir/"}).Return("Removing dir/\n", nil)
+
+	result, err = app.DiscardFile("dir/", result.ConfirmToken)
+
+	assert.NoError(t, err)
+	assert.False(t, result.NeedsConfirmation)
+	assert.Equal(t, []string{"dir/a.txt", "dir/sub/b.txt"}, result.Paths)
+	assert.NotEmpty(t, result.SnapshotID)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDiscardHunk(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"a.txt": "ONE\n"})
+	mockExec.On("Execute", []string{"diff", "--", "a.txt"}).Return(discardDiff, nil)
+	expectSnapshot(mockExec, "discard hunk of a.txt", []string{"a.txt"}, []string{"ONE\n"})
+	patch := "diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n" +
+		"@@ -9,2 +9,3 @@\n nine\n ten\n+eleven\n"
+	mockExec.On("ExecuteWithInput", patch, []string{"apply", "--reverse", "--recount"}).Return("", nil)
+
+	result, err := app.DiscardHunk("a.txt", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt"}, result.Paths)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDiscardHunk_ZeroContextLines(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"a.txt": "ONE\n"})
+	require.NoError(t, app.SetDiffOptions(types.DiffOptions{ContextLines: intPtr(0)}))
+	mockExec.On("Execute", []string{"diff", "-U0", "--", "a.txt"}).Return(
+		"diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-one\n+ONE\n", nil)
+	expectSnapshot(mockExec, "discard hunk of a.txt", []string{"a.txt"}, []string{"ONE\n"})
+	patch := "diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n" +
+		"@@ -1,1 +1,1 @@\n-one\n+ONE\n"
+	mockExec.On("ExecuteWithInput", patch, []string{"apply", "--reverse", "--recount", "--unidiff-zero"}).Return("", nil)
+
+	_, err := app.DiscardHunk("a.txt", 0)
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDiscardLines(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"a.txt": "ONE\n"})
+	mockExec.On("Execute", []string{"diff", "--", "a.txt"}).Return(discardDiff, nil)
+	expectSnapshot(mockExec, "discard hunk of a.txt", []string{"a.txt"}, []string{"ONE\n"})
+	patch := "diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n" +
+		"@@ -1,1 +1,2 @@\n+ONE\n two\n"
+	mockExec.On("ExecuteWithInput", patch, []string{"apply", "--reverse", "--recount"}).Return("", nil)
+
+	_, err := app.DiscardLines("a.txt", 0, []int{1})
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDiscardHunk_PatchDropsWordDiff(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, nil)
+	require.NoError(t, app.SetDiffOptions(types.DiffOptions{WordDiff: true, ContextLines: intPtr(5)}))
+	mockExec.On("Execute", []string{"diff", "-U5", "--", "a.txt"}).Return(discardDiff, nil)
+
+	_, err := app.DiscardHunk("a.txt", 2)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "out of range")
+	mockExec.AssertExpectations(t)
+}
+
+func TestDiscardHunk_RefusedWhileIgnoringWhitespace(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, nil)
+	require.NoError(t, app.SetDiffOptions(types.DiffOptions{IgnoreAllSpace: true}))
+
+	_, err := app.DiscardHunk("a.txt", 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "whitespace changes are ignored")
+	mockExec.AssertNotCalled(t, "Execute", mock.Anything)
+}
+
+func TestDiscardLines_RefusedInWordDiff(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, nil)
+	require.NoError(t, app.SetDiffOptions(types.DiffOptions{WordDiff: true}))
+
+	_, err := app.DiscardLines("a.txt", 0, []int{1})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "word diff")
+	mockExec.AssertNotCalled(t, "Execute", mock.Anything)
+}
+
+func TestCleanUntracked_DryRun(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, nil)
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "-z", "--", "build"}).
+		Return("build/a.o\x00build/b.o\x00", nil)
+
+	result, err := app.CleanUntracked([]string{"build"}, true)
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, []string{"build/a.o", "build/b.o"}, result.Paths)
+	assert.Empty(t, result.SnapshotID)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCleanUntracked(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"naïve.txt": "log\n"})
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "-z", "--"}).Return("naïve.txt\x00", nil)
+	expectSnapshot(mockExec, "clean", []string{"naïve.txt"}, []string{"log\n"})
+	mockExec.On("Execute", []string{"clean", "-f", "--", "naïve.txt"}).Return("Removing \"na\\303\\257ve.txt\"\n", nil)
+
+	result, err := app.CleanUntracked(nil, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"naïve.txt"}, result.Paths)
+	assert.NotEmpty(t, result.SnapshotID)
+	mockExec.AssertExpectations(t)
+}
+
+func TestListSnapshots(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"for-each-ref", "--sort=-refname",
+		"--format=%(refname:lstrip=3)%00%(creatordate:unix)%00%(contents:subject)%00%(contents:body)%1e",
+		"refs/gitgui/snapshots/"}).Return("100\x001700000000\x00clean\x00tmp.log\n\x1e\n", nil)
+
+	app := newTestApp(mockExec)
+	snapshots, err := app.ListSnapshots()
+
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, []string{"tmp.log"}, snapshots[0].Paths)
+	mockExec.AssertExpectations(t)
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, nil)
+	mockExec.On("Execute", []string{"ls-tree", "-z", "refs/gitgui/snapshots/100"}).
+		Return("100644 blob 1111\t0\x00100755 blob 2222\t1\x00100644 blob 3333\tpaths\x00", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", "3333"}).Return("sub/a.txt\x00run.sh\x00", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", "1111"}).Return("restored\n", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", "2222"}).Return("#!/bin/sh\n", nil)
+	mockExec.On("Execute", []string{"update-ref", "-d", "refs/gitgui/snapshots/100"}).Return("", nil)
+
+	err := app.RestoreSnapshot("100")
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestRestoreSnapshot_ReplacesSymlinkAndKeepsNewlinePath(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	app := newDiscardApp(t, mockExec, map[string]string{"target.txt": "keep\n"})
+	repo, err := app.GetCurrentRepo()
+	require.NoError(t, err)
+	require.NoError(t, os.Symlink("target.txt", filepath.Join(repo.Path, "a.txt")))
+	mockExec.On("Execute", []string{"ls-tree", "-z", "refs/gitgui/snapshots/100"}).
+		Return("100644 blob 1111\t0\x00100644 blob 2222\t1\x00100644 blob 3333\tpaths\x00", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", "3333"}).Return("a.txt\x00odd\nname.txt\x00", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", "1111"}).Return("restored\n", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", "2222"}).Return("odd\n", nil)
+	mockExec.On("Execute", []string{"update-ref", "-d", "refs/gitgui/snapshots/100"}).Return("", nil)
+
+	err = app.RestoreSnapshot("100")
+
+	assert.NoError(t, err)
+	info, err := os.Lstat(filepath.Join(repo.Path, "a.txt"))
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular())
+	content, err := os.ReadFile(filepath.Join(repo.Path, "target.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "keep\n", string(content))
+	content, err = os.ReadFile(filepath.Join(repo.Path, "odd\nname.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "odd\n", string(content))
+	mockExec.AssertExpectations(t)
+}
+
+func TestRestoreSnapshot_InvalidID(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	err := app.RestoreSnapshot("../heads/main")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid snapshot id")
+}
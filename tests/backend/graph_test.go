@@ -0,0 +1,59 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func graphLog(skip, count string) []string {
+	return []string{"log", "--topo-order", git.GraphCommitFormat, "--skip=" + skip, "-n" + count,
+		"--branches", "--remotes", "--tags", "HEAD", "--"}
+}
+
+func TestGetCommitGraph_Pages(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", graphLog("0", "1")).Return(shaTwo+"\x00"+shaOne+"\x00Jane\x001\x00Second\n", nil)
+	mockExec.On("Execute", graphLog("1", "1")).Return(shaOne+"\x00\x00Jane\x001\x00First\n", nil)
+
+	app := newTestApp(mockExec)
+	first, err := app.GetCommitGraph(0, 1)
+	assert.NoError(t, err)
+	assert.True(t, first.HasMore)
+	assert.Len(t, first.Rows, 1)
+
+	second, err := app.GetCommitGraph(1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, second.Offset)
+	assert.Len(t, second.Rows, 1)
+	assert.Equal(t, shaOne, second.Rows[0].Commit.SHA)
+	// The line from the first page continues into the second.
+	assert.Len(t, second.Rows[0].Edges, 1)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetCommitGraph_OutOfOrderOffset(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", graphLog("0", "3")).
+		Return(shaTwo+"\x00"+shaOne+"\x00Jane\x001\x00Second\n"+shaOne+"\x00\x00Jane\x001\x00First\n", nil)
+
+	app := newTestApp(mockExec)
+	page, err := app.GetCommitGraph(1, 2)
+
+	assert.NoError(t, err)
+	assert.False(t, page.HasMore)
+	assert.Len(t, page.Rows, 1)
+	assert.Equal(t, shaOne, page.Rows[0].Commit.SHA)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetCommitGraph_InvalidWindow(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	_, err := app.GetCommitGraph(-1, 10)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid graph window")
+}
@@ -0,0 +1,71 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const historyOutput = "\x1e" + shaTwo + "\x00Jane\x00jane@example.com\x001700000200\x00Edit\x00\x00\n" +
+	":100644 100644 aaaaaaa bbbbbbb M\x00a.txt\x00\x00diff --git a/a.txt b/a.txt\n" +
+	"index aaaaaaa..bbbbbbb 100644\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-one\n+two\n" +
+	"\x1e" + shaOne + "\x00Jane\x00jane@example.com\x001700000100\x00Add\x00\x00\n" +
+	":000000 100644 0000000 aaaaaaa A\x00a.txt\x00\x00diff --git a/a.txt b/a.txt\n" +
+	"new file mode 100644\nindex 0000000..aaaaaaa\n--- /dev/null\n+++ b/a.txt\n@@ -0,0 +1 @@\n+one\n"
+
+func TestGetFileHistory(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"log", "--follow", "-M", git.FileHistoryFormat, "--raw", "-p", "-z", "-n2", "--", "a.txt"}).
+		Return(historyOutput, nil)
+
+	app := newTestApp(mockExec)
+	history, err := app.GetFileHistory("a.txt", 2, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, shaTwo, history[0].SHA)
+	assert.Equal(t, types.DiffRevisions, history[0].Diff.Mode)
+	assert.Len(t, history[0].Diff.Hunks, 1)
+	assert.Empty(t, history[0].Diff.Diff)
+	assert.True(t, history[1].Diff.IsNew)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetFileHistory_Offset(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"log", "--follow", "-M", git.FileHistoryFormat, "--raw", "-p", "-z", "-n2", "--", "a.txt"}).
+		Return(historyOutput, nil)
+
+	app := newTestApp(mockExec)
+	history, err := app.GetFileHistory("a.txt", 1, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, shaOne, history[0].SHA)
+}
+
+func TestGetFileHistory_DiffOptions(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"log", "--follow", "-M", git.FileHistoryFormat, "--raw", "-p", "-z", "-w", "-n1", "--", "a.txt"}).
+		Return("", nil)
+
+	app := newTestApp(mockExec)
+	assert.NoError(t, app.SetDiffOptions(types.DiffOptions{IgnoreAllSpace: true}))
+	history, err := app.GetFileHistory("a.txt", 1, 0)
+
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetFileHistory_InvalidWindow(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	_, err := app.GetFileHistory("a.txt", 0, 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid history window")
+}
@@ -0,0 +1,265 @@
+package backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git-gui/backend"
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	shaOne   = "1111111111111111111111111111111111111111"
+	shaTwo   = "2222222222222222222222222222222222222222"
+	treeOne  = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	treeTwo  = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	mainHead = "refs/heads/main"
+)
+
+var missingRef = &git.GitError{Args: []string{"rev-parse"}, ExitCode: 1}
+
+// expectState mocks one capture of the repository state. An empty head means
+// a detached HEAD; refs are resolved after HEAD, in the order given.
+func expectState(mockExec *MockGitExecutor, head, sha, tree string, refs ...[2]string) {
+	if head == "" {
+		mockExec.On("Execute", []string{"symbolic-ref", "-q", "HEAD"}).Return("", &git.GitError{ExitCode: 1}).Once()
+	} else {
+		mockExec.On("Execute", []string{"symbolic-ref", "-q", "HEAD"}).Return(head+"\n", nil).Once()
+	}
+	expectRef(mockExec, "HEAD", sha)
+	for _, ref := range refs {
+		expectRef(mockExec, ref[0], ref[1])
+	}
+	mockExec.On("Execute", []string{"write-tree"}).Return(tree+"\n", nil).Once()
+}
+
+func expectRef(mockExec *MockGitExecutor, ref, sha string) {
+	if sha == "" {
+		mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", ref}).Return("", missingRef).Once()
+	} else {
+		mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", ref}).Return(sha+"\n", nil).Once()
+	}
+}
+
+func newJournaledApp(mockExec *MockGitExecutor) *backend.App {
+	return backend.NewJournaledTestApp(mockExec, &types.GitRepo{Path: "/test/repo", CurrentBranch: "main"})
+}
+
+func TestJournal_RecordsCommit(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectState(mockExec, mainHead, shaOne, treeTwo, [2]string{"HEAD", shaOne})
+	mockExec.On("Execute", []string{"commit", "-m", "msg"}).Return("[main 2222222] msg\n", nil)
+	expectCommitStats(mockExec, "")
+	expectState(mockExec, mainHead, shaTwo, treeTwo, [2]string{"HEAD", shaTwo})
+
+	app := newJournaledApp(mockExec)
+	_, err := app.CommitIndex("msg")
+	assert.NoError(t, err)
+
+	history, err := app.GetOperationHistory()
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, "commit", history[0].Operation)
+	assert.Equal(t, shaOne, history[0].Before.HeadSHA)
+	assert.Equal(t, shaTwo, history[0].After.Refs["HEAD"])
+	assert.Equal(t, "main 1111111 -> 2222222", history[0].Description)
+	assert.False(t, history[0].Undone)
+	mockExec.AssertExpectations(t)
+}
+
+func TestJournal_SkipsNoOpOperations(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectState(mockExec, mainHead, shaOne, treeOne)
+	mockExec.On("Execute", []string{"add", "--all"}).Return("", nil)
+	expectState(mockExec, mainHead, shaOne, treeOne)
+
+	app := newJournaledApp(mockExec)
+	assert.NoError(t, app.StageAll())
+
+	history, err := app.GetOperationHistory()
+
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestJournal_UndoRedoCommit(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectState(mockExec, mainHead, shaOne, treeOne, [2]string{"HEAD", shaOne})
+	mockExec.On("Execute", []string{"add", "--", "a.txt"}).Return("", nil)
+	expectPartialCommit(t, mockExec, "")
+	mockExec.On("Execute", []string{"commit", "--only", "-m", "msg", "--", "a.txt"}).Return("[main 2222222] msg\n", nil)
+	expectCommitStats(mockExec, "")
+	expectState(mockExec, mainHead, shaTwo, treeTwo, [2]string{"HEAD", shaTwo})
+
+	app := newJournaledApp(mockExec)
+	_, err := app.CommitFiles([]string{"a.txt"}, "msg")
+	assert.NoError(t, err)
+
+	expectState(mockExec, mainHead, shaTwo, treeTwo, [2]string{"HEAD", shaTwo})
+	mockExec.On("Execute", []string{"update-ref", "HEAD", shaOne, shaTwo}).Return("", nil)
+	mockExec.On("Execute", []string{"read-tree", treeOne}).Return("", nil)
+
+	undone, err := app.Undo()
+
+	assert.NoError(t, err)
+	assert.True(t, undone.Undone)
+	history, _ := app.GetOperationHistory()
+	assert.True(t, history[0].Undone)
+
+	expectState(mockExec, mainHead, shaOne, treeOne, [2]string{"HEAD", shaOne})
+	mockExec.On("Execute", []string{"update-ref", "HEAD", shaTwo, shaOne}).Return("", nil)
+	mockExec.On("Execute", []string{"read-tree", treeTwo}).Return("", nil)
+
+	_, err = app.Redo()
+
+	assert.NoError(t, err)
+	history, _ = app.GetOperationHistory()
+	assert.False(t, history[0].Undone)
+	mockExec.AssertExpectations(t)
+}
+
+func TestJournal_UndoCreateBranch(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"check-ref-format", "--branch", "feature"}).Return("feature\n", nil)
+	expectState(mockExec, mainHead, shaOne, treeOne, [2]string{"refs/heads/feature", ""})
+	mockExec.On("Execute", []string{"checkout", "-b", "feature"}).Return("", nil)
+	gitDir := newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/feature\n"})
+	expectState(mockExec, "refs/heads/feature", shaOne, treeOne, [2]string{"refs/heads/feature", shaOne})
+
+	app := newJournaledApp(mockExec)
+	assert.NoError(t, app.CreateBranch("feature"))
+
+	expectState(mockExec, "refs/heads/feature", shaOne, treeOne, [2]string{"refs/heads/feature", shaOne})
+	mockExec.On("Execute", []string{"checkout", "-q", "main"}).Run(func(mock.Arguments) {
+		require.NoError(t, os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644))
+	}).Return("", nil)
+	mockExec.On("Execute", []string{"update-ref", "-d", "refs/heads/feature", shaOne}).Return("", nil)
+
+	_, err := app.Undo()
+
+	assert.NoError(t, err)
+	repo, _ := app.GetCurrentRepo()
+	assert.Equal(t, "main", repo.CurrentBranch)
+	assert.Equal(t, "main", repo.HeadState.Branch)
+	mockExec.AssertExpectations(t)
+}
+
+func TestJournal_UndoRefusesWhenRepositoryChanged(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectState(mockExec, mainHead, shaOne, treeOne)
+	mockExec.On("Execute", []string{"checkout", "feature"}).Return("", nil)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/feature\n"})
+	expectState(mockExec, "refs/heads/feature", shaTwo, treeOne)
+
+	app := newJournaledApp(mockExec)
+	assert.NoError(t, app.SwitchBranch("feature"))
+
+	expectState(mockExec, "refs/heads/other", shaTwo, treeOne)
+
+	_, err := app.Undo()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the repository has changed since")
+	mockExec.AssertNotCalled(t, "Execute", []string{"checkout", "-q", "main"})
+}
+
+func TestJournal_UndoRefusesWhenIndexChanged(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectState(mockExec, mainHead, shaOne, treeOne, [2]string{"HEAD", shaOne})
+	mockExec.On("Execute", []string{"commit", "-m", "msg"}).Return("[main 2222222] msg\n", nil)
+	expectCommitStats(mockExec, "")
+	expectState(mockExec, mainHead, shaTwo, treeTwo, [2]string{"HEAD", shaTwo})
+
+	app := newJournaledApp(mockExec)
+	_, err := app.CommitIndex("msg")
+	assert.NoError(t, err)
+
+	// More changes were staged after the commit.
+	expectState(mockExec, mainHead, shaTwo, treeOne, [2]string{"HEAD", shaTwo})
+
+	_, err = app.Undo()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the repository has changed since")
+	mockExec.AssertNotCalled(t, "Execute", []string{"read-tree", treeOne})
+}
+
+func TestJournal_SavedInGitDir(t *testing.T) {
+	gitDir := t.TempDir()
+	repo := &types.GitRepo{Path: "/test/repo", CurrentBranch: "main"}
+
+	mockExec := new(MockGitExecutor)
+	expectState(mockExec, mainHead, shaOne, treeOne)
+	mockExec.On("Execute", []string{"add", "--all"}).Return("", nil)
+	expectState(mockExec, mainHead, shaOne, treeTwo)
+	pins := "update refs/gitgui/journal/1/" + shaOne + " " + shaOne + "\n" +
+		"update refs/gitgui/journal/1/" + treeOne + " " + treeOne + "\n" +
+		"update refs/gitgui/journal/1/" + treeTwo + " " + treeTwo + "\n"
+	mockExec.On("ExecuteWithInput", pins, []string{"update-ref", "--stdin"}).Return("", nil)
+
+	app := backend.NewSavedJournalTestApp(mockExec, repo, gitDir)
+	assert.NoError(t, app.StageAll())
+	mockExec.AssertExpectations(t)
+
+	reopened := new(MockGitExecutor)
+	expectState(reopened, mainHead, shaOne, treeTwo)
+	reopened.On("Execute", []string{"read-tree", treeOne}).Return("", nil)
+
+	app = backend.NewSavedJournalTestApp(reopened, repo, gitDir)
+	history, err := app.GetOperationHistory()
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+	assert.Equal(t, "stage all", history[0].Operation)
+
+	_, err = app.Undo()
+	assert.NoError(t, err)
+	reopened.AssertExpectations(t)
+
+	app = backend.NewSavedJournalTestApp(new(MockGitExecutor), repo, gitDir)
+	history, _ = app.GetOperationHistory()
+	assert.True(t, history[0].Undone)
+}
+
+func TestJournal_NewOperationClearsRedo(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectState(mockExec, mainHead, shaOne, treeOne)
+	mockExec.On("Execute", []string{"add", "--all"}).Return("", nil)
+	expectState(mockExec, mainHead, shaOne, treeTwo)
+
+	app := newJournaledApp(mockExec)
+	assert.NoError(t, app.StageAll())
+
+	expectState(mockExec, mainHead, shaOne, treeTwo)
+	mockExec.On("Execute", []string{"read-tree", treeOne}).Return("", nil)
+	_, err := app.Undo()
+	assert.NoError(t, err)
+
+	expectState(mockExec, mainHead, shaOne, treeOne)
+	mockExec.On("Execute", []string{"add", "--", "b.txt"}).Return("", nil)
+	expectState(mockExec, mainHead, shaOne, treeTwo)
+	assert.NoError(t, app.StageFiles([]string{"b.txt"}))
+
+	history, _ := app.GetOperationHistory()
+	assert.Len(t, history, 1)
+	assert.Equal(t, "stage", history[0].Operation)
+
+	_, err = app.Redo()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to redo")
+}
+
+func TestJournal_NothingToUndo(t *testing.T) {
+	app := newJournaledApp(new(MockGitExecutor))
+
+	_, err := app.Undo()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to undo")
+}
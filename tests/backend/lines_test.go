@@ -0,0 +1,74 @@
+package backend_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git-gui/backend"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFileLines_Revision(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"cat-file", "blob", "HEAD:main.go"}).
+		Return("package main\n\nimport \"fmt\"\n\nfunc main() {\n}\n", nil)
+
+	app := newTestApp(mockExec)
+	lines, err := app.GetFileLines("main.go", "HEAD", 3, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"import \"fmt\"", "", "func main() {"}, lines.Lines)
+	assert.Equal(t, 6, lines.TotalLines)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetFileLines_IndexClampsRange(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"cat-file", "blob", ":notes.txt"}).
+		Return("one\ntwo\nthree", nil)
+
+	app := newTestApp(mockExec)
+	lines, err := app.GetFileLines("notes.txt", ":", 2, 50)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lines.Start)
+	assert.Equal(t, 3, lines.End)
+	assert.Equal(t, []string{"two", "three"}, lines.Lines)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetFileLines_WorkingTree(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("a\nb\nc\n"), 0o644))
+
+	app := backend.NewTestApp(new(MockGitExecutor), &types.GitRepo{Path: root})
+	lines, err := app.GetFileLines("a.txt", "", 1, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, lines.Lines)
+	assert.Equal(t, 3, lines.TotalLines)
+}
+
+func TestGetFileLines_Errors(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"cat-file", "blob", "HEAD:gone.txt"}).
+		Return("", errors.New("fatal: path 'gone.txt' does not exist in 'HEAD'"))
+	app := newTestApp(mockExec)
+
+	_, err := app.GetFileLines("a.txt", "HEAD", 5, 2)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid line range")
+
+	_, err = app.GetFileLines("../secret", "", 1, 2)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outside the repository")
+
+	_, err = app.GetFileLines("gone.txt", "HEAD", 1, 2)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read gone.txt at HEAD")
+}
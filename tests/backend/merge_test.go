@@ -0,0 +1,153 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const featureSHA = "3333333333333333333333333333333333333333"
+
+// notAncestor is how git merge-base --is-ancestor answers no.
+var notAncestor = &git.GitError{Args: []string{"merge-base"}, ExitCode: 1}
+
+func expectDiverged(mockExec *MockGitExecutor) {
+	expectHeadAndRevision(mockExec, "feature", featureSHA)
+	mockExec.On("Execute", []string{"merge-base", "--is-ancestor", featureSHA, shaTwo}).Return("", notAncestor)
+	mockExec.On("Execute", []string{"merge-base", "--is-ancestor", shaTwo, featureSHA}).Return("", notAncestor)
+}
+
+func TestPreviewMerge_Conflict(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectDiverged(mockExec)
+	mockExec.On("Execute", []string{"merge-tree", "--write-tree", "-z", "--name-only", "--no-messages", shaTwo, featureSHA}).
+		Return("", &git.GitError{Output: treeOne + "\x00a.txt\x00", ExitCode: 1})
+
+	app := newTestApp(mockExec)
+	preview, err := app.PreviewMerge("feature")
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.MergeConflict, preview.Outcome)
+	assert.Equal(t, treeOne, preview.Tree)
+	assert.Equal(t, []string{"a.txt"}, preview.Conflicts)
+	mockExec.AssertExpectations(t)
+}
+
+func TestPreviewMerge_Clean(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectDiverged(mockExec)
+	mockExec.On("Execute", []string{"merge-tree", "--write-tree", "-z", "--name-only", "--no-messages", shaTwo, featureSHA}).
+		Return(treeOne+"\x00", nil)
+
+	app := newTestApp(mockExec)
+	preview, err := app.PreviewMerge("feature")
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.MergeCommitted, preview.Outcome)
+	assert.Empty(t, preview.Conflicts)
+}
+
+func TestPreviewMerge_FastForward(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "feature", featureSHA)
+	mockExec.On("Execute", []string{"merge-base", "--is-ancestor", featureSHA, shaTwo}).Return("", notAncestor)
+	mockExec.On("Execute", []string{"merge-base", "--is-ancestor", shaTwo, featureSHA}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	preview, err := app.PreviewMerge("feature")
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.MergeFastForward, preview.Outcome)
+	mockExec.AssertNotCalled(t, "Execute", []string{"merge-tree", "--write-tree", "-z", "--name-only", "--no-messages", shaTwo, featureSHA})
+}
+
+func TestMerge_UpToDate(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "feature", featureSHA)
+	mockExec.On("Execute", []string{"merge-base", "--is-ancestor", featureSHA, shaTwo}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Merge("feature", types.MergeOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.MergeUpToDate, result.Outcome)
+	mockExec.AssertExpectations(t)
+}
+
+func TestMerge_Committed(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectDiverged(mockExec)
+	mockExec.On("Execute", []string{"merge", "--no-edit", "--no-ff", "-X", "theirs", "-m", "Merge feature", "feature"}).Return("", nil)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--cached", shaTwo}).Return("", nil)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaOne+"\n", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Merge("feature", types.MergeOptions{
+		FastForward:    types.FastForwardNever,
+		Message:        "Merge feature",
+		StrategyOption: types.StrategyTheirs,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.MergeCommitted, result.Outcome)
+	assert.Equal(t, shaOne, result.HeadSHA)
+	mockExec.AssertExpectations(t)
+}
+
+func TestMerge_Conflict(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectDiverged(mockExec)
+	mockExec.On("Execute", []string{"merge", "--no-edit", "--squash", "feature"}).Return("", assert.AnError)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("a.txt\x00", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Merge("feature", types.MergeOptions{Squash: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.MergeConflict, result.Outcome)
+	assert.Equal(t, []string{"a.txt"}, result.Conflicts)
+	mockExec.AssertExpectations(t)
+}
+
+func TestMerge_Failed(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectDiverged(mockExec)
+	mockExec.On("Execute", []string{"merge", "--no-edit", "feature"}).Return("", assert.AnError)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	_, err := app.Merge("feature", types.MergeOptions{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to merge feature")
+}
+
+func TestMerge_FastForwardOnlyDiverged(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectDiverged(mockExec)
+
+	app := newTestApp(mockExec)
+	_, err := app.Merge("feature", types.MergeOptions{FastForward: types.FastForwardOnly})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot fast-forward")
+}
+
+func TestMerge_InvalidOptions(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	_, err := app.Merge("feature", types.MergeOptions{FastForward: types.FastForwardNever, Squash: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "squash")
+
+	_, err = app.Merge("feature", types.MergeOptions{StrategyOption: "recursive"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown strategy option")
+
+	_, err = app.Merge("feature", types.MergeOptions{FastForward: "sometimes"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown fast-forward mode")
+}
@@ -0,0 +1,140 @@
+package backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newGitDir creates a fake git directory with the given state files and
+// registers it as the answer to `rev-parse --absolute-git-dir`.
+func newGitDir(t *testing.T, mockExec *MockGitExecutor, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	mockExec.On("Execute", []string{"rev-parse", "--absolute-git-dir"}).Return(dir+"\n", nil)
+	return dir
+}
+
+func TestGetOperationState_RebaseWithConflicts(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{
+		"HEAD":                   "abc1234def5678abc1234def5678abc1234def56\n",
+		"rebase-merge/head-name": "refs/heads/feature\n",
+		"rebase-merge/msgnum":    "3\n",
+		"rebase-merge/end":       "7\n",
+	})
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).
+		Return("a.txt\x00b.txt\x00", nil)
+
+	app := newTestApp(mockExec)
+	state, err := app.GetOperationState()
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.OperationRebase, state.Operation)
+	assert.Equal(t, "feature", state.Branch)
+	assert.Equal(t, 3, state.Step)
+	assert.Equal(t, 7, state.TotalSteps)
+	assert.Equal(t, []string{"a.txt", "b.txt"}, state.Conflicts)
+	assert.True(t, state.CanSkip)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetOperationState_None(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+	})
+
+	app := newTestApp(mockExec)
+	state, err := app.GetOperationState()
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.OperationNone, state.Operation)
+	assert.Empty(t, state.Conflicts)
+	mockExec.AssertExpectations(t)
+}
+
+func TestContinueOperation_Merge(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	dir := newGitDir(t, mockExec, map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+		"MERGE_HEAD":      "89abcdef0123456789abcdef0123456789abcdef\n",
+	})
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).
+		Return("", nil)
+	mockExec.On("ExecuteWithEnv", []string{"GIT_EDITOR=true"}, []string{"merge", "--continue"}).
+		Return("", nil).
+		Run(func(mock.Arguments) { os.Remove(filepath.Join(dir, "MERGE_HEAD")) })
+
+	app := newTestApp(mockExec)
+	state, err := app.ContinueOperation()
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.OperationNone, state.Operation)
+	mockExec.AssertExpectations(t)
+}
+
+func TestAbortOperation_Bisect(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	dir := newGitDir(t, mockExec, map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+		"BISECT_LOG":      "git bisect start\n",
+	})
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).
+		Return("", nil)
+	mockExec.On("ExecuteWithEnv", []string{"GIT_EDITOR=true"}, []string{"bisect", "reset"}).
+		Return("", nil).
+		Run(func(mock.Arguments) { os.Remove(filepath.Join(dir, "BISECT_LOG")) })
+
+	app := newTestApp(mockExec)
+	state, err := app.AbortOperation()
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.OperationNone, state.Operation)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSkipOperation_MergeNotSkippable(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+		"MERGE_HEAD":      "89abcdef0123456789abcdef0123456789abcdef\n",
+	})
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).
+		Return("", nil)
+
+	app := newTestApp(mockExec)
+	_, err := app.SkipOperation()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be skipped")
+}
+
+func TestContinueOperation_NothingInProgress(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+	})
+
+	app := newTestApp(mockExec)
+	_, err := app.ContinueOperation()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no operation in progress")
+}
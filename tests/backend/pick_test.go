@@ -0,0 +1,132 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCherryPick_Completed(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/main\n"})
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("Execute", []string{"cherry-pick", "-x", "-m", "1", shaOne}).Return("", nil)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--cached", shaTwo}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.CherryPick([]string{shaOne}, types.CherryPickOptions{RecordOrigin: true, Mainline: 1})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Completed)
+	assert.Equal(t, types.OperationCherryPick, result.Operation)
+	assert.Empty(t, result.Conflicts)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCherryPick_StoppedOnConflict(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{
+		"HEAD":             "ref: refs/heads/main\n",
+		"CHERRY_PICK_HEAD": shaOne + "\n",
+	})
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("Execute", []string{"cherry-pick", shaOne, "3333333"}).Return("", assert.AnError)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("a.txt\x00", nil)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "CHERRY_PICK_HEAD"}).Return(shaOne+"\n", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.CherryPick([]string{shaOne, "3333333"}, types.CherryPickOptions{})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Completed)
+	assert.Equal(t, shaOne, result.StoppedAt)
+	assert.Equal(t, []string{"a.txt"}, result.Conflicts)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCherryPick_NoCommitStoppedOnConflict(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/main\n"})
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("Execute", []string{"cherry-pick", "--no-commit", shaOne}).Return("", assert.AnError)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("a.txt\x00", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.CherryPick([]string{shaOne}, types.CherryPickOptions{NoCommit: true})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Completed)
+	assert.Empty(t, result.StoppedAt)
+	assert.Equal(t, shaTwo, result.HeadSHA)
+	assert.Equal(t, []string{"a.txt"}, result.Conflicts)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCherryPick_Failed(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/main\n"})
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("Execute", []string{"cherry-pick", shaOne}).Return("", assert.AnError)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	_, err := app.CherryPick([]string{shaOne}, types.CherryPickOptions{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to cherry-pick")
+}
+
+func TestCherryPick_InvalidInput(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	_, err := app.CherryPick(nil, types.CherryPickOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no commits to cherry-pick")
+
+	_, err = app.CherryPick([]string{"--abort"}, types.CherryPickOptions{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid revision")
+
+	_, err = app.CherryPick([]string{shaOne}, types.CherryPickOptions{Mainline: -1})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid mainline parent")
+}
+
+func TestRevert_NoCommit(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{"HEAD": "ref: refs/heads/main\n"})
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("Execute", []string{"revert", "--no-edit", "--no-commit", shaOne}).Return("", nil)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--cached", shaTwo}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Revert([]string{shaOne}, types.RevertOptions{NoCommit: true})
+
+	assert.NoError(t, err)
+	assert.True(t, result.Completed)
+	assert.Equal(t, types.OperationRevert, result.Operation)
+	assert.Equal(t, shaTwo, result.HeadSHA)
+	mockExec.AssertExpectations(t)
+}
+
+func TestRevert_StoppedOnConflict(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, map[string]string{
+		"HEAD":        "ref: refs/heads/main\n",
+		"REVERT_HEAD": shaOne + "\n",
+	})
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("Execute", []string{"revert", "--no-edit", shaOne}).Return("", assert.AnError)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("a.txt\x00", nil)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "REVERT_HEAD"}).Return(shaOne+"\n", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Revert([]string{shaOne}, types.RevertOptions{})
+
+	assert.NoError(t, err)
+	assert.False(t, result.Completed)
+	assert.Equal(t, types.OperationRevert, result.Operation)
+	assert.Equal(t, shaOne, result.StoppedAt)
+}
@@ -0,0 +1,174 @@
+package backend_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git-gui/backend/rebase"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// rebaseGitDir holds the files git leaves while a rebase is stopped.
+var rebaseGitDir = map[string]string{
+	"HEAD":                   shaTwo + "\n",
+	"rebase-merge/head-name": "refs/heads/feature\n",
+	"rebase-merge/msgnum":    "2\n",
+	"rebase-merge/end":       "3\n",
+	"rebase-merge/done":      "pick " + shaOne + " First\nedit " + shaTwo + " Second\n",
+}
+
+func rebasePlan() types.RebasePlan {
+	return types.RebasePlan{
+		Onto:    "main",
+		OntoSHA: "3333333333333333333333333333333333333333",
+		HeadSHA: shaTwo,
+		Steps: []types.RebaseStep{
+			{Action: types.RebasePick, SHA: shaOne, Subject: "First"},
+			{Action: types.RebaseEdit, SHA: shaTwo, Subject: "Second"},
+			{Action: types.RebaseExec, Command: "go test ./..."},
+		},
+	}
+}
+
+func TestPlanRebase(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "main", "3333333333333333333333333333333333333333")
+	mockExec.On("Execute", []string{"log", "--reverse", "--no-merges", "--format=%H%x00%s",
+		"3333333333333333333333333333333333333333.." + shaTwo}).
+		Return(shaOne+"\x00First\n"+shaTwo+"\x00Second\n", nil)
+
+	app := newTestApp(mockExec)
+	plan, err := app.PlanRebase("main")
+
+	assert.NoError(t, err)
+	assert.Equal(t, shaTwo, plan.HeadSHA)
+	assert.Equal(t, []types.RebaseStep{
+		{Action: types.RebasePick, SHA: shaOne, Subject: "First"},
+		{Action: types.RebasePick, SHA: shaTwo, Subject: "Second"},
+	}, plan.Steps)
+	mockExec.AssertExpectations(t)
+}
+
+func TestExecuteRebase_Completed(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	dir := newGitDir(t, mockExec, map[string]string{
+		"HEAD":               "ref: refs/heads/feature\n",
+		"refs/heads/feature": "4444444444444444444444444444444444444444\n",
+	})
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("ExecuteWithEnv", mock.Anything, []string{"rebase", "-i", "3333333333333333333333333333333333333333"}).
+		Return("Successfully rebased and updated refs/heads/feature.\n", nil)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--cached", shaTwo}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.ExecuteRebase(rebasePlan())
+
+	assert.NoError(t, err)
+	assert.True(t, result.Completed)
+	assert.NoFileExists(t, filepath.Join(dir, rebase.PlanFile))
+	mockExec.AssertExpectations(t)
+}
+
+func TestExecuteRebase_StoppedAtEdit(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	dir := newGitDir(t, mockExec, rebaseGitDir)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("ExecuteWithEnv", mock.MatchedBy(func(env []string) bool {
+		return len(env) == 2 && strings.HasPrefix(env[0], "GIT_SEQUENCE_EDITOR=") && strings.HasPrefix(env[1], "GIT_EDITOR=")
+	}), []string{"rebase", "-i", "3333333333333333333333333333333333333333"}).Return("Stopped at 2222222\n", nil)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.ExecuteRebase(rebasePlan())
+
+	assert.NoError(t, err)
+	assert.False(t, result.Completed)
+	assert.Equal(t, types.RebaseStopEdit, result.Stop)
+	assert.Equal(t, shaTwo, result.StoppedAt)
+	assert.Equal(t, 2, result.Step)
+	assert.Equal(t, 3, result.TotalSteps)
+
+	plan, err := os.ReadFile(filepath.Join(dir, rebase.PlanFile))
+	assert.NoError(t, err)
+	assert.Contains(t, string(plan), "edit "+shaTwo+" Second")
+	mockExec.AssertExpectations(t)
+}
+
+func TestExecuteRebase_StoppedOnConflict(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	newGitDir(t, mockExec, rebaseGitDir)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("ExecuteWithEnv", mock.Anything, mock.Anything).Return("", assert.AnError)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("a.txt\x00", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.ExecuteRebase(rebasePlan())
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.RebaseStopConflict, result.Stop)
+	assert.Equal(t, []string{"a.txt"}, result.Conflicts)
+}
+
+func TestExecuteRebase_HeadMoved(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaOne+"\n", nil)
+
+	app := newTestApp(mockExec)
+	_, err := app.ExecuteRebase(rebasePlan())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "HEAD has moved")
+}
+
+func TestExecuteRebase_InvalidSteps(t *testing.T) {
+	tests := []struct {
+		steps []types.RebaseStep
+		msg   string
+	}{
+		{nil, "no steps"},
+		{[]types.RebaseStep{{Action: types.RebaseSquash, SHA: shaOne}}, "without a previous commit"},
+		{[]types.RebaseStep{{Action: types.RebaseDrop, SHA: shaOne}, {Action: types.RebaseFixup, SHA: shaTwo}}, "without a previous commit"},
+		{[]types.RebaseStep{{Action: "merge", SHA: shaOne}}, "unknown action"},
+		{[]types.RebaseStep{{Action: types.RebasePick, SHA: "HEAD; rm -rf /"}}, "invalid commit"},
+		{[]types.RebaseStep{{Action: types.RebaseExec, Command: "make\nmake install"}}, "single-line command"},
+	}
+
+	app := newTestApp(new(MockGitExecutor))
+	for _, tt := range tests {
+		plan := rebasePlan()
+		plan.Steps = tt.steps
+		_, err := app.ExecuteRebase(plan)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), tt.msg)
+	}
+}
+
+func TestContinueOperation_PlannedRebaseKeepsEditor(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	files := map[string]string{rebase.PlanFile: "{}"}
+	for name, content := range rebaseGitDir {
+		files[name] = content
+	}
+	dir := newGitDir(t, mockExec, files)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "--diff-filter=U"}).Return("", nil)
+	mockExec.On("ExecuteWithEnv", mock.Anything, []string{"rebase", "--continue"}).
+		Return("", nil).
+		Run(func(mock.Arguments) {
+			os.RemoveAll(filepath.Join(dir, "rebase-merge"))
+			os.WriteFile(filepath.Join(dir, "HEAD"), []byte("ref: refs/heads/feature\n"), 0o644)
+		})
+
+	app := newTestApp(mockExec)
+	state, err := app.ContinueOperation()
+
+	assert.NoError(t, err)
+	assert.Equal(t, types.OperationNone, state.Operation)
+	assert.NoFileExists(t, filepath.Join(dir, rebase.PlanFile))
+	mockExec.AssertExpectations(t)
+}
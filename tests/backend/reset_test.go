@@ -0,0 +1,142 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectHeadAndRevision mocks resolving HEAD and the target revision.
+func expectHeadAndRevision(mockExec *MockGitExecutor, revision, target string) {
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil).Once()
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", revision + "^{commit}"}).Return(target+"\n", nil).Once()
+}
+
+func TestPreviewReset_Hard(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "HEAD~1", shaOne)
+	mockExec.On("Execute", []string{"log", "--format=%H%x00%s", shaOne + ".." + shaTwo, "--not", "--remotes"}).
+		Return(shaTwo+"\x00Local work\n", nil)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", "HEAD"}).Return("a.txt\x00", nil)
+
+	app := newTestApp(mockExec)
+	preflight, err := app.PreviewReset("HEAD~1", types.ResetHard)
+
+	assert.NoError(t, err)
+	assert.Equal(t, shaOne, preflight.TargetSHA)
+	assert.Equal(t, []types.CommitSummary{{SHA: shaTwo, Subject: "Local work"}}, preflight.UnpushedCommits)
+	assert.Equal(t, []string{"a.txt"}, preflight.DiscardedFiles)
+	mockExec.AssertExpectations(t)
+}
+
+func TestPreviewReset_SoftListsNoFiles(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "HEAD~1", shaOne)
+	mockExec.On("Execute", []string{"log", "--format=%H%x00%s", shaOne + ".." + shaTwo, "--not", "--remotes"}).
+		Return("", nil)
+
+	app := newTestApp(mockExec)
+	preflight, err := app.PreviewReset("HEAD~1", types.ResetSoft)
+
+	assert.NoError(t, err)
+	assert.Empty(t, preflight.UnpushedCommits)
+	assert.Empty(t, preflight.DiscardedFiles)
+	mockExec.AssertExpectations(t)
+}
+
+func TestResetTo_Soft(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "HEAD~1", shaOne)
+	mockExec.On("Execute", []string{"reset", "--soft", shaOne}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.ResetTo("HEAD~1", types.ResetSoft)
+
+	assert.NoError(t, err)
+	assert.Equal(t, shaTwo, result.PreviousSHA)
+	assert.Empty(t, result.RecoveryRef)
+	mockExec.AssertExpectations(t)
+}
+
+func TestResetTo_HardStoresRecoveryRef(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "main~2", shaOne)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", shaOne}).Return("", nil)
+	mockExec.On("Execute", []string{"stash", "create", "reset recovery"}).Return("3333333333333333333333333333333333333333\n", nil)
+	mockExec.On("Execute", mock.MatchedBy(func(args []string) bool {
+		return len(args) == 3 && args[0] == "update-ref" && args[2] == "3333333333333333333333333333333333333333"
+	})).Return("", nil)
+	mockExec.On("Execute", []string{"reset", "--hard", shaOne}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.ResetTo("main~2", types.ResetHard)
+
+	assert.NoError(t, err)
+	assert.Contains(t, result.RecoveryRef, "refs/gitgui/recovery/")
+	mockExec.AssertExpectations(t)
+}
+
+func TestResetTo_HardWithoutChangesRecoversHead(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "HEAD~1", shaOne)
+	mockExec.On("Execute", []string{"diff", "--name-only", "-z", shaOne}).Return("", nil)
+	mockExec.On("Execute", []string{"stash", "create", "reset recovery"}).Return("", nil)
+	mockExec.On("Execute", mock.MatchedBy(func(args []string) bool {
+		return len(args) == 3 && args[0] == "update-ref" && args[2] == shaTwo
+	})).Return("", nil)
+	mockExec.On("Execute", []string{"reset", "--hard", shaOne}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	_, err := app.ResetTo("HEAD~1", types.ResetHard)
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestResetTo_UnknownRevision(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return(shaTwo+"\n", nil)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "nope^{commit}"}).
+		Return("", &git.GitError{ExitCode: 1})
+
+	app := newTestApp(mockExec)
+	_, err := app.ResetTo("nope", types.ResetMixed)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown revision")
+}
+
+func TestResetTo_InvalidMode(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+	_, err := app.ResetTo("HEAD~1", types.ResetMode("merge"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown reset mode")
+}
+
+func TestResetTo_Undo(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectHeadAndRevision(mockExec, "HEAD~1", shaOne)
+	expectState(mockExec, mainHead, shaTwo, treeTwo, [2]string{"HEAD", shaTwo})
+	mockExec.On("Execute", []string{"reset", "--mixed", shaOne}).Return("", nil)
+	expectState(mockExec, mainHead, shaOne, treeOne, [2]string{"HEAD", shaOne})
+
+	app := newJournaledApp(mockExec)
+	_, err := app.ResetTo("HEAD~1", types.ResetMixed)
+	require.NoError(t, err)
+
+	expectState(mockExec, mainHead, shaOne, treeOne, [2]string{"HEAD", shaOne})
+	mockExec.On("Execute", []string{"update-ref", "HEAD", shaTwo, shaOne}).Return("", nil)
+	mockExec.On("Execute", []string{"read-tree", treeTwo}).Return("", nil)
+
+	entry, err := app.Undo()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "reset", entry.Operation)
+	mockExec.AssertExpectations(t)
+}
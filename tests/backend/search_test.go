@@ -0,0 +1,120 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func searchCommit(sha, subject string) string {
+	return "\x1e" + sha + "\x00Jane\x00jane@example.com\x001700000000\x00" + subject + "\x00\x00"
+}
+
+func TestSearch_Message(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"log", git.SearchCommitFormat, "-z", "-n201", "--grep=fix", "-i", "-F", "HEAD", "--"}).
+		Return(searchCommit(shaOne, "Fix parser"), nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Search(types.SearchQuery{Mode: types.SearchMessage, Pattern: "fix", IgnoreCase: true, Fixed: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Commits, 1)
+	assert.Equal(t, "Fix parser", result.Commits[0].Subject)
+	assert.Empty(t, result.Matches)
+	assert.False(t, result.Truncated)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSearch_Author(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"log", git.SearchCommitFormat, "-z", "-n2", "--author=Jane", "main", "--", "src"}).
+		Return(searchCommit(shaTwo, "Second")+searchCommit(shaOne, "First"), nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Search(types.SearchQuery{
+		Mode:     types.SearchAuthor,
+		Pattern:  "Jane",
+		Revision: "main",
+		Paths:    []string{"src"},
+		Limit:    1,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Commits, 1)
+	assert.True(t, result.Truncated)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSearch_Pickaxe(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"log", git.SearchCommitFormat, "-z", "-n201", "--name-only", "-SparseConfig", "HEAD", "--"}).
+		Return(searchCommit(shaOne, "Remove parseConfig")+"\na.go\x00", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Search(types.SearchQuery{Mode: types.SearchPickaxe, Pattern: "parseConfig"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.go"}, result.Commits[0].Files)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSearch_PickaxeRegex(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"log", git.SearchCommitFormat, "-z", "-n201", "--name-only", "-Gfunc \\w+Config", "HEAD", "--"}).
+		Return("", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Search(types.SearchQuery{Mode: types.SearchPickaxeRegex, Pattern: "func \\w+Config", Fixed: true})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Commits)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSearch_Content(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"grep", "-n", "-z", "--column", "--full-name", "-C1", "-e", "-needle", "HEAD", "--"}).
+		Return("HEAD:a.txt\x001\x00before\nHEAD:a.txt\x002\x003\x00a -needle\n", nil)
+
+	app := newTestApp(mockExec)
+	result, err := app.Search(types.SearchQuery{Mode: types.SearchContent, Pattern: "-needle", Revision: "HEAD", Context: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []types.ContentMatch{
+		{Path: "a.txt", LineNumber: 2, Column: 3, Content: "a -needle", Before: []string{"before"}, After: []string{}},
+	}, result.Matches)
+	assert.Empty(t, result.Commits)
+	mockExec.AssertExpectations(t)
+}
+
+func TestSearch_ContentNoMatches(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"grep", "-n", "-z", "--column", "--full-name", "-e", "needle", "--"}).
+		Return("", &git.GitError{ExitCode: 1})
+
+	app := newTestApp(mockExec)
+	result, err := app.Search(types.SearchQuery{Mode: types.SearchContent, Pattern: "needle"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Matches)
+}
+
+func TestSearch_InvalidQuery(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	_, err := app.Search(types.SearchQuery{Mode: types.SearchContent})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "search pattern required")
+
+	_, err = app.Search(types.SearchQuery{Mode: "regex", Pattern: "x"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown search mode")
+
+	_, err = app.Search(types.SearchQuery{Mode: types.SearchContent, Pattern: "x", Revision: "--all"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid revision")
+}
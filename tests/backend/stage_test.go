@@ -0,0 +1,112 @@
+package backend_test
+
+import (
+	"errors"
+	"testing"
+
+	"git-gui/backend/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var unbornHead = &git.GitError{Args: []string{"rev-parse", "--verify", "-q", "HEAD"}, ExitCode: 1}
+
+func TestStageFiles(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"add", "--", "a.txt", "removed.txt"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.StageFiles([]string{"a.txt", "removed.txt"})
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestStageFiles_NoFiles(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+	err := app.StageFiles(nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no files to stage")
+}
+
+func TestStageAll(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"add", "--all"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.StageAll()
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestUnstageFiles(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return("abc1234\n", nil)
+	mockExec.On("Execute", []string{"restore", "--staged", "--", "a.txt"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.UnstageFiles([]string{"a.txt"})
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestUnstageFiles_UnbornBranch(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return("", unbornHead)
+	mockExec.On("Execute", []string{"rm", "--cached", "-r", "-q", "--ignore-unmatch", "--", "a.txt"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.UnstageFiles([]string{"a.txt"})
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestUnstageFiles_Error(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return("abc1234\n", nil)
+	mockExec.On("Execute", []string{"restore", "--staged", "--", "a.txt"}).Return("", errors.New("index.lock exists"))
+
+	app := newTestApp(mockExec)
+	err := app.UnstageFiles([]string{"a.txt"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to unstage files")
+}
+
+func TestUnstageAll_UnbornBranch(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"rev-parse", "--verify", "-q", "HEAD"}).Return("", unbornHead)
+	mockExec.On("Execute", []string{"rm", "--cached", "-r", "-q", "--ignore-unmatch", "--", "."}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.UnstageAll()
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCommitIndex(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"commit", "-m", "staged only"}).
+		Return("[main 1a2b3c4] staged only\n 1 file changed\n", nil)
+	expectCommitStats(mockExec, "")
+
+	app := newTestApp(mockExec)
+	result, err := app.CommitIndex("staged only")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1a2b3c4", result.CommitSHA)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCommitIndex_EmptyMessage(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+	_, err := app.CommitIndex("")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "commit message required")
+}
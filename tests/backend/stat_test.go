@@ -0,0 +1,63 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChangeStats(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--numstat", "-z", "-M", "--cached"}).
+		Return("120\t4\ta.txt\x00-\t-\timage.png\x00", nil)
+	mockExec.On("Execute", []string{"diff", "--numstat", "-z", "-M"}).
+		Return("1\t0\ta.txt\x00", nil)
+	mockExec.On("Execute", []string{"ls-files", "--others", "--exclude-standard", "-z"}).
+		Return("new.txt\x00data.bin\x00", nil)
+
+	app := newDiscardApp(t, mockExec, map[string]string{"new.txt": "one\ntwo", "data.bin": "a\x00b\n"})
+	stats, err := app.GetChangeStats()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []types.FileStat{
+		{Path: "a.txt", Insertions: 120, Deletions: 4},
+		{Path: "image.png", Binary: true},
+	}, stats.Staged.Files)
+	assert.Equal(t, 2, stats.Staged.FilesChanged)
+	assert.Equal(t, 120, stats.Staged.Insertions)
+	assert.Equal(t, 4, stats.Staged.Deletions)
+	assert.Equal(t, []types.FileStat{
+		{Path: "a.txt", Insertions: 1},
+		{Path: "new.txt", Insertions: 2},
+		{Path: "data.bin", Binary: true},
+	}, stats.Unstaged.Files)
+	assert.Equal(t, 3, stats.Unstaged.FilesChanged)
+	assert.Equal(t, 3, stats.Unstaged.Insertions)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetChangeStats_GitError(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--numstat", "-z", "-M", "--cached"}).Return("", assert.AnError)
+
+	app := newTestApp(mockExec)
+	_, err := app.GetChangeStats()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get staged change stats")
+}
+
+func TestCommitIndex_StatsUnavailable(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"commit", "-m", "msg"}).Return("[main 1a2b3c4] msg\n", nil)
+	mockExec.On("Execute", []string{"show", "--numstat", "-z", "-M", "--format=", "HEAD"}).Return("", assert.AnError)
+
+	app := newTestApp(mockExec)
+	result, err := app.CommitIndex("msg")
+
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Nil(t, result.Stats)
+}
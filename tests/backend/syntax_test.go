@@ -0,0 +1,106 @@
+package backend_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDiffHighlight_LinguistLanguage(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "build.inc"}).
+		Return("@@ -1 +1 @@\n-<?php echo 1;\n+<?php echo 2;\n", nil)
+	mockExec.On("Execute", []string{"check-attr", "linguist-language", "--", "build.inc"}).
+		Return("build.inc: linguist-language: PHP\n", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", "HEAD:build.inc"}).
+		Return("<?php echo 1;\n", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", ":build.inc"}).
+		Return("<?php echo 2;\n", nil)
+
+	app := newTestApp(mockExec)
+	highlighted, err := app.GetDiffHighlight("build.inc", types.DiffTarget{Mode: types.DiffIndexToHead})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PHP", highlighted.Language)
+	assert.Len(t, highlighted.Hunks[0].Lines, 2)
+	assert.NotEmpty(t, highlighted.Hunks[0].Lines[0].Tokens)
+	assert.NotEmpty(t, highlighted.Hunks[0].Lines[1].Tokens)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetDiffHighlight_UnknownLanguage(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "data.zzz"}).
+		Return("@@ -1 +1 @@\n-a\n+b\n", nil)
+	mockExec.On("Execute", []string{"check-attr", "linguist-language", "--", "data.zzz"}).
+		Return("data.zzz: linguist-language: unspecified\n", nil)
+
+	app := newTestApp(mockExec)
+	highlighted, err := app.GetDiffHighlight("data.zzz", types.DiffTarget{Mode: types.DiffIndexToHead})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", highlighted.Language)
+	assert.Len(t, highlighted.Hunks, 1)
+	mockExec.AssertExpectations(t)
+}
+
+// manyGoHunks builds a diff of main.go with n single-line hunks, and the
+// old and new versions of the file it compares.
+func manyGoHunks(n int) (diff, oldSource, newSource string) {
+	var d, o, w strings.Builder
+	d.WriteString("diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n")
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&d, "@@ -%d +%d @@\n-var a%d = 1\n+var a%d = 2\n", i, i, i, i)
+		fmt.Fprintf(&o, "var a%d = 1\n", i)
+		fmt.Fprintf(&w, "var a%d = 2\n", i)
+	}
+	return d.String(), o.String(), w.String()
+}
+
+func TestGetDiffHighlight_TruncatedDiff(t *testing.T) {
+	diff, _, _ := manyGoHunks(400)
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "main.go"}).Return(diff, nil)
+	mockExec.On("Execute", []string{"check-attr", "linguist-language", "--", "main.go"}).
+		Return("main.go: linguist-language: unspecified\n", nil)
+
+	app := newTestApp(mockExec)
+	highlighted, err := app.GetDiffHighlight("main.go", types.DiffTarget{Mode: types.DiffIndexToHead})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", highlighted.Language)
+	assert.Empty(t, highlighted.Hunks)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetDiffHunksHighlight_Window(t *testing.T) {
+	diff, oldSource, newSource := manyGoHunks(400)
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"diff", "--cached", "--", "main.go"}).Return(diff, nil)
+	mockExec.On("Execute", []string{"check-attr", "linguist-language", "--", "main.go"}).
+		Return("main.go: linguist-language: unspecified\n", nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", "HEAD:main.go"}).Return(oldSource, nil)
+	mockExec.On("Execute", []string{"cat-file", "blob", ":main.go"}).Return(newSource, nil)
+
+	app := newTestApp(mockExec)
+	highlighted, err := app.GetDiffHunksHighlight("main.go", types.DiffTarget{Mode: types.DiffIndexToHead}, 390, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, highlighted.Hunks, 10)
+	assert.Len(t, highlighted.Hunks[0].Lines, 2)
+	assert.NotEmpty(t, highlighted.Hunks[0].Lines[0].Tokens)
+	assert.NotEmpty(t, highlighted.Hunks[0].Lines[1].Tokens)
+	mockExec.AssertExpectations(t)
+}
+
+func TestGetDiffHunksHighlight_InvalidWindow(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+	_, err := app.GetDiffHunksHighlight("main.go", types.DiffTarget{Mode: types.DiffIndexToHead}, 0, 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid hunk window")
+}
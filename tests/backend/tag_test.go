@@ -0,0 +1,136 @@
+package backend_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func expectValidTag(mockExec *MockGitExecutor, name string) {
+	mockExec.On("Execute", []string{"check-ref-format", "refs/tags/" + name}).Return("", nil)
+}
+
+func TestListTags(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"for-each-ref", "--sort=-creatordate", git.TagFormat, "refs/tags/"}).
+		Return("v1\x00commit\x00"+shaOne+"\x00\x00\x00\x001700000000\x00Initial\x00\x00\x1e\n", nil)
+
+	app := newTestApp(mockExec)
+	tags, err := app.ListTags()
+
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+	assert.Equal(t, "v1", tags[0].Name)
+	assert.Equal(t, shaOne, tags[0].Target)
+	assert.False(t, tags[0].Annotated)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCreateTag_Lightweight(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectValidTag(mockExec, "v1")
+	mockExec.On("Execute", []string{"tag", "v1", "HEAD"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.CreateTag("v1", "", "", false)
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCreateTag_Annotated(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectValidTag(mockExec, "v1")
+	mockExec.On("Execute", []string{"tag", "-a", "-m", "Release 1", "v1", shaOne}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.CreateTag("v1", shaOne, "Release 1", false)
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCreateTag_Signed(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectValidTag(mockExec, "v1")
+	mockExec.On("Execute", []string{"tag", "-s", "-m", "Release 1", "v1", "HEAD"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.CreateTag("v1", "", "Release 1", true)
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestCreateTag_SignedWithoutMessage(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectValidTag(mockExec, "v1")
+
+	app := newTestApp(mockExec)
+	err := app.CreateTag("v1", "", "", true)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a message")
+}
+
+func TestCreateTag_InvalidName(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	mockExec.On("Execute", []string{"check-ref-format", "refs/tags/bad..name"}).Return("", assert.AnError)
+
+	app := newTestApp(mockExec)
+
+	err := app.CreateTag("bad..name", "", "", false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid tag name")
+
+	err = app.CreateTag("-d", "", "", false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid tag name")
+}
+
+func TestDeleteTag(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectValidTag(mockExec, "v1")
+	mockExec.On("Execute", []string{"tag", "-d", "v1"}).Return("Deleted tag 'v1'\n", nil)
+
+	app := newTestApp(mockExec)
+	err := app.DeleteTag("v1")
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestPushTag(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectValidTag(mockExec, "v1")
+	mockExec.On("Execute", []string{"push", "origin", "refs/tags/v1"}).Return("", nil)
+
+	app := newTestApp(mockExec)
+	err := app.PushTag("origin", "v1")
+
+	assert.NoError(t, err)
+	mockExec.AssertExpectations(t)
+}
+
+func TestDeleteRemoteTag(t *testing.T) {
+	mockExec := new(MockGitExecutor)
+	expectValidTag(mockExec, "v1")
+	mockExec.On("Execute", []string{"push", "origin", "--delete", "refs/tags/v1"}).Return("", assert.AnError)
+
+	app := newTestApp(mockExec)
+	err := app.DeleteRemoteTag("origin", "v1")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to delete tag v1 from origin")
+}
+
+func TestPushTag_InvalidRemote(t *testing.T) {
+	app := newTestApp(new(MockGitExecutor))
+
+	err := app.PushTag("--mirror", "v1")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid remote")
+}
@@ -0,0 +1,65 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBlame(t *testing.T) {
+	output := "bce8b4dea72cf6928b7a95bd4ec6b2425ae79f6a 2 2 1\n" +
+		"author Jane\n" +
+		"author-mail <jane@example.com>\n" +
+		"author-time 1792358762\n" +
+		"author-tz +0000\n" +
+		"summary Rewrite line two\n" +
+		"previous df1fc3e109554b30c13dac1b73fb6d1f8a5a3647 h\n" +
+		"filename h\n" +
+		"\tX\n" +
+		"df1fc3e109554b30c13dac1b73fb6d1f8a5a3647 1 3 1\n" +
+		"author John\n" +
+		"author-mail <john@example.com>\n" +
+		"author-time 1792350000\n" +
+		"summary Add h\n" +
+		"filename old/h\n" +
+		"\t3\n" +
+		"bce8b4dea72cf6928b7a95bd4ec6b2425ae79f6a 4 4\n" +
+		"\t\tindented\n"
+
+	lines, err := git.ParseBlame(output)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []types.BlameLine{
+		{
+			LineNumber: 2, OriginalLine: 2, OriginalPath: "h",
+			SHA: "bce8b4dea72cf6928b7a95bd4ec6b2425ae79f6a", Author: "Jane", AuthorEmail: "jane@example.com",
+			AuthorTime: 1792358762, Summary: "Rewrite line two", Content: "X",
+		},
+		{
+			LineNumber: 3, OriginalLine: 1, OriginalPath: "old/h",
+			SHA: "df1fc3e109554b30c13dac1b73fb6d1f8a5a3647", Author: "John", AuthorEmail: "john@example.com",
+			AuthorTime: 1792350000, Summary: "Add h", Content: "3",
+		},
+		{
+			LineNumber: 4, OriginalLine: 4, OriginalPath: "h",
+			SHA: "bce8b4dea72cf6928b7a95bd4ec6b2425ae79f6a", Author: "Jane", AuthorEmail: "jane@example.com",
+			AuthorTime: 1792358762, Summary: "Rewrite line two", Content: "\tindented",
+		},
+	}, lines)
+}
+
+func TestParseBlame_InvalidHeader(t *testing.T) {
+	_, err := git.ParseBlame("not a header\n\tline\n")
+
+	assert.Error(t, err)
+}
+
+func TestParseBlame_Empty(t *testing.T) {
+	lines, err := git.ParseBlame("")
+
+	assert.NoError(t, err)
+	assert.Empty(t, lines)
+}
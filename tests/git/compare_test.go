@@ -0,0 +1,41 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNameStatus(t *testing.T) {
+	files := git.ParseNameStatus("A\x00n f.txt\x00R087\x00g.txt\x00g2.txt\x00M\x00y.txt\x00D\x00old.txt\x00")
+
+	assert.Equal(t, []types.ComparedFile{
+		{Path: "n f.txt", Status: types.StatusAdded},
+		{Path: "g2.txt", OldPath: "g.txt", Status: types.StatusRenamed},
+		{Path: "y.txt", Status: types.StatusModified},
+		{Path: "old.txt", Status: types.StatusDeleted},
+	}, files)
+}
+
+func TestParseNameStatus_Empty(t *testing.T) {
+	assert.Empty(t, git.ParseNameStatus(""))
+}
+
+func TestSplitDiff(t *testing.T) {
+	first := "diff --git a/a.txt b/a.txt\nindex 1..2 100644\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-diff --git x\n+y\n"
+	second := "diff --git a/b b/b\nnew file mode 100644\nBinary files /dev/null and b/b differ\n"
+
+	assert.Equal(t, []string{first, second}, git.SplitDiff(first+second))
+	assert.Empty(t, git.SplitDiff(""))
+}
+
+func TestSplitDiff_TypeChangeStaysTogether(t *testing.T) {
+	deletion := "diff --git a/f b/f\ndeleted file mode 100644\n--- a/f\n+++ /dev/null\n@@ -1 +0,0 @@\n-hello\n"
+	creation := "diff --git a/f b/f\nnew file mode 120000\n--- /dev/null\n+++ b/f\n@@ -0,0 +1 @@\n+target\n"
+	other := "diff --git a/g b/g\nindex 1..2 100644\n"
+
+	assert.Equal(t, []string{deletion + creation, other}, git.SplitDiff(deletion+creation+other))
+}
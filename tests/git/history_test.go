@@ -0,0 +1,56 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const fileHistoryOutput = "\x1e2222222222222222222222222222222222222222\x00Jane\x00jane@example.com\x001700000200\x00Rename\x00\x00\n" +
+	":100644 100644 e25a3fb e25a3fb R100\x00h\x00h new\x00\x00diff --git a/h b/h new\n" +
+	"similarity index 100%\nrename from h\nrename to h new\n" +
+	"\x1e1111111111111111111111111111111111111111\x00John\x00john@example.com\x001700000100\x00Add h\x00\x00\n" +
+	":000000 100644 0000000 01e79c3 A\x00h\x00\x00diff --git a/h b/h\n" +
+	"new file mode 100644\nindex 0000000..01e79c3\n--- /dev/null\n+++ b/h\n@@ -0,0 +1 @@\n+1\n"
+
+func TestParseFileHistory(t *testing.T) {
+	records, err := git.ParseFileHistory("h new", fileHistoryOutput)
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, types.FileHistoryEntry{
+		SHA:         "2222222222222222222222222222222222222222",
+		Author:      "Jane",
+		AuthorEmail: "jane@example.com",
+		AuthorTime:  1700000200,
+		Subject:     "Rename",
+		Path:        "h new",
+		OldPath:     "h",
+		Status:      types.StatusRenamed,
+	}, records[0].Entry)
+	assert.Contains(t, records[0].Diff, "rename to h new")
+
+	assert.Equal(t, "h", records[1].Entry.Path)
+	assert.Equal(t, types.StatusAdded, records[1].Entry.Status)
+	assert.Contains(t, records[1].Diff, "@@ -0,0 +1 @@\n+1\n")
+}
+
+func TestParseFileHistory_MergeKeepsPath(t *testing.T) {
+	output := "\x1e3333333333333333333333333333333333333333\x00Jane\x00jane@example.com\x001\x00Merge\x00\x00\n"
+
+	records, err := git.ParseFileHistory("a.txt", output)
+
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "a.txt", records[0].Entry.Path)
+	assert.Empty(t, records[0].Diff)
+}
+
+func TestParseFileHistory_Invalid(t *testing.T) {
+	_, err := git.ParseFileHistory("a.txt", "\x1enot a record")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommitSummaries(t *testing.T) {
+	output := "1111111111111111111111111111111111111111\x00Add feature\n" +
+		"2222222222222222222222222222222222222222\x00Fix: handle a\x00b\n"
+
+	commits := git.ParseCommitSummaries(output)
+
+	assert.Equal(t, []types.CommitSummary{
+		{SHA: "1111111111111111111111111111111111111111", Subject: "Add feature"},
+		{SHA: "2222222222222222222222222222222222222222", Subject: "Fix: handle a\x00b"},
+	}, commits)
+}
+
+func TestParseCommitSummaries_Empty(t *testing.T) {
+	assert.Empty(t, git.ParseCommitSummaries(""))
+}
+
+func TestParseGraphCommits(t *testing.T) {
+	output := "3333333333333333333333333333333333333333\x001111111111111111111111111111111111111111 2222222222222222222222222222222222222222\x00Jane\x001700000000\x00Merge branch 'feature'\n" +
+		"1111111111111111111111111111111111111111\x00\x00John\x001690000000\x00Initial commit\n"
+
+	commits := git.ParseGraphCommits(output)
+
+	assert.Equal(t, []types.GraphCommit{
+		{
+			SHA:        "3333333333333333333333333333333333333333",
+			Parents:    []string{"1111111111111111111111111111111111111111", "2222222222222222222222222222222222222222"},
+			Author:     "Jane",
+			AuthorTime: 1700000000,
+			Subject:    "Merge branch 'feature'",
+		},
+		{
+			SHA:        "1111111111111111111111111111111111111111",
+			Parents:    []string{},
+			Author:     "John",
+			AuthorTime: 1690000000,
+			Subject:    "Initial commit",
+		},
+	}, commits)
+}
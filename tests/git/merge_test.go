@@ -0,0 +1,23 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMergeTree_Conflicts(t *testing.T) {
+	tree, conflicts := git.ParseMergeTree("0ed40fed2fe32b400abeaf74ce3d6ff1b92f0490\x00f\x00sp ace\x00")
+
+	assert.Equal(t, "0ed40fed2fe32b400abeaf74ce3d6ff1b92f0490", tree)
+	assert.Equal(t, []string{"f", "sp ace"}, conflicts)
+}
+
+func TestParseMergeTree_Clean(t *testing.T) {
+	tree, conflicts := git.ParseMergeTree("94698bc45a5f9f0dcec0b161f1af8497dedb9f4c\x00")
+
+	assert.Equal(t, "94698bc45a5f9f0dcec0b161f1af8497dedb9f4c", tree)
+	assert.Empty(t, conflicts)
+}
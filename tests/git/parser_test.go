@@ -145,8 +145,9 @@ index abc123..def456 100644
 	assert.Equal(t, 1, hunk.NewStart)
 	assert.Equal(t, 4, hunk.NewLines)
 	assert.Len(t, hunk.Lines, 4)
-	assert.Equal(t, " line1", hunk.Lines[0])
-	assert.Equal(t, "+added line", hunk.Lines[1])
+	assert.Equal(t, types.DiffLine{Kind: types.LineContext, OldLineNo: 1, NewLineNo: 1, Content: "line1"}, hunk.Lines[0])
+	assert.Equal(t, types.DiffLine{Kind: types.LineAdd, NewLineNo: 2, Content: "added line"}, hunk.Lines[1])
+	assert.Equal(t, types.DiffLine{Kind: types.LineContext, OldLineNo: 2, NewLineNo: 3, Content: "line2"}, hunk.Lines[2])
 }
 
 func TestParseDiff_EmptyOutput(t *testing.T) {
@@ -203,6 +204,16 @@ func TestParseHunkHeader_WithContext(t *testing.T) {
 	assert.Equal(t, 8, hunk.NewLines)
 }
 
+func TestParseHunkHeader_OmittedCounts(t *testing.T) {
+	hunk, err := git.ParseHunkHeader("@@ -5 +7 @@")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, hunk.OldStart)
+	assert.Equal(t, 1, hunk.OldLines)
+	assert.Equal(t, 7, hunk.NewStart)
+	assert.Equal(t, 1, hunk.NewLines)
+}
+
 func TestParseHunkHeader_InvalidFormat(t *testing.T) {
 	_, err := git.ParseHunkHeader("not a hunk header")
 
@@ -222,3 +233,279 @@ func TestExtractCommitSHA_NoMatch(t *testing.T) {
 
 	assert.Equal(t, "", sha)
 }
+
+func TestParseDiff_NewFileHeader(t *testing.T) {
+	input := `diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..3e75765
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++new`
+
+	result, err := git.ParseDiff("new.txt", input)
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsNew)
+	assert.False(t, result.IsDeleted)
+	assert.Equal(t, "100644", result.NewMode)
+	assert.Equal(t, "0000000", result.OldBlob)
+	assert.Equal(t, "3e75765", result.NewBlob)
+	assert.Len(t, result.Hunks, 1)
+}
+
+func TestParseDiff_ModeChange(t *testing.T) {
+	input := `diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755`
+
+	result, err := git.ParseDiff("run.sh", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "100644", result.OldMode)
+	assert.Equal(t, "100755", result.NewMode)
+	assert.Empty(t, result.Hunks)
+}
+
+func TestParseDiff_Binary(t *testing.T) {
+	input := `diff --git a/logo.png b/logo.png
+index 8352675..eaf36c1 100644
+Binary files a/logo.png and b/logo.png differ`
+
+	result, err := git.ParseDiff("logo.png", input)
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsBinary)
+	assert.Equal(t, "100644", result.OldMode)
+	assert.Equal(t, "8352675", result.OldBlob)
+	assert.Equal(t, "eaf36c1", result.NewBlob)
+}
+
+func TestParseDiff_SymlinkTargetChange(t *testing.T) {
+	input := `diff --git a/link b/link
+index 8d14cbf..19acdd8 120000
+--- a/link
++++ b/link
+@@ -1 +1 @@
+-a.txt
+\ No newline at end of file
++b.txt
+\ No newline at end of file`
+
+	result, err := git.ParseDiff("link", input)
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsSymlink)
+	assert.Equal(t, "a.txt", result.OldSymlinkTarget)
+	assert.Equal(t, "b.txt", result.NewSymlinkTarget)
+}
+
+func TestParseHunkLines_NumbersAndNoNewline(t *testing.T) {
+	raw := []string{
+		" context",
+		"-old",
+		"\\ No newline at end of file",
+		"+new",
+		"\\ No newline at end of file",
+		"",
+	}
+
+	lines := git.ParseHunkLines(10, 20, raw)
+
+	assert.Len(t, lines, 5)
+	assert.Equal(t, 10, lines[0].OldLineNo)
+	assert.Equal(t, 20, lines[0].NewLineNo)
+	assert.Equal(t, types.LineDelete, lines[1].Kind)
+	assert.Equal(t, 11, lines[1].OldLineNo)
+	assert.Equal(t, 0, lines[1].NewLineNo)
+	assert.Equal(t, types.LineNoNewline, lines[2].Kind)
+	assert.Equal(t, "No newline at end of file", lines[2].Content)
+	assert.Equal(t, types.LineAdd, lines[3].Kind)
+	assert.Equal(t, 0, lines[3].OldLineNo)
+	assert.Equal(t, 21, lines[3].NewLineNo)
+}
+
+func TestParseHunkLines_IntraLineChanges(t *testing.T) {
+	raw := []string{
+		`-{"name": "app", "version": "1.2.0"}`,
+		`+{"name": "app", "version": "1.3.0"}`,
+	}
+
+	lines := git.ParseHunkLines(1, 1, raw)
+
+	assert.Equal(t, []types.LineSpan{{Start: 30, End: 31}}, lines[0].Changes)
+	assert.Equal(t, []types.LineSpan{{Start: 30, End: 31}}, lines[1].Changes)
+}
+
+func TestWordDiff(t *testing.T) {
+	tests := []struct {
+		old, new           string
+		oldSpans, newSpans []types.LineSpan
+	}{
+		{"return a + b", "return a - b", []types.LineSpan{{Start: 9, End: 10}}, []types.LineSpan{{Start: 9, End: 10}}},
+		{"foo(bar)", "foo(bar, baz)", nil, []types.LineSpan{{Start: 7, End: 12}}},
+		{"héllo wörld", "héllo world", []types.LineSpan{{Start: 6, End: 11}}, []types.LineSpan{{Start: 6, End: 11}}},
+		{"completely", "different", nil, nil},
+	}
+
+	for _, tt := range tests {
+		oldSpans, newSpans := git.WordDiff(tt.old, tt.new)
+		assert.Equal(t, tt.oldSpans, oldSpans, tt.old)
+		assert.Equal(t, tt.newSpans, newSpans, tt.new)
+	}
+}
+
+func TestParseHunkHeader_Section(t *testing.T) {
+	hunk, err := git.ParseHunkHeader("@@ -10,6 +12,8 @@ func main()")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "func main()", hunk.Section)
+}
+
+func TestParseDiff_TypeChangeToSymlink(t *testing.T) {
+	input := `diff --git a/f b/f
+deleted file mode 100644
+index ce01362..0000000
+--- a/f
++++ /dev/null
+@@ -1 +0,0 @@
+-hello
+diff --git a/f b/f
+new file mode 120000
+index 0000000..1de5659
+--- /dev/null
++++ b/f
+@@ -0,0 +1 @@
++target
+\ No newline at end of file
+`
+
+	result, err := git.ParseDiff("f", input)
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsSymlink)
+	assert.False(t, result.IsNew)
+	assert.False(t, result.IsDeleted)
+	assert.Equal(t, "100644", result.OldMode)
+	assert.Equal(t, "120000", result.NewMode)
+	assert.Equal(t, "ce01362", result.OldBlob)
+	assert.Equal(t, "1de5659", result.NewBlob)
+	assert.Equal(t, "", result.OldSymlinkTarget)
+	assert.Equal(t, "target", result.NewSymlinkTarget)
+	assert.Equal(t, 1, result.Summary.FilesChanged)
+	assert.Len(t, result.Hunks, 2)
+	assert.Equal(t, []types.DiffLine{{Kind: types.LineDelete, OldLineNo: 1, Content: "hello"}}, result.Hunks[0].Lines)
+	assert.Len(t, result.Hunks[1].Lines, 2)
+	assert.Equal(t, types.LineAdd, result.Hunks[1].Lines[0].Kind)
+	assert.Equal(t, types.LineNoNewline, result.Hunks[1].Lines[1].Kind)
+}
+
+func TestParseDiff_HunkEndsAtLineCounts(t *testing.T) {
+	input := `@@ -1,2 +1,2 @@
+ keep
+-old
++new
+--- not part of the hunk
+`
+
+	result, err := git.ParseDiff("file.txt", input)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Hunks, 1)
+	assert.Len(t, result.Hunks[0].Lines, 3)
+}
+
+func TestParseDiffHunks_Window(t *testing.T) {
+	input := "diff --git a/f b/f\n--- a/f\n+++ b/f\n" +
+		"@@ -1 +1 @@\n-a\n+A\n" +
+		"@@ -10 +10 @@\n-b\n+B\n" +
+		"@@ -20 +20 @@\n-c\n+C\n"
+
+	hunks, total := git.ParseDiffHunks(input, 1, 1)
+
+	assert.Equal(t, 3, total)
+	assert.Len(t, hunks, 1)
+	assert.Equal(t, 10, hunks[0].OldStart)
+	assert.Equal(t, "B", hunks[0].Lines[1].Content)
+
+	hunks, total = git.ParseDiffHunks(input, 5, 2)
+
+	assert.Equal(t, 3, total)
+	assert.Empty(t, hunks)
+}
+
+func TestParseDiff_WordDiffPorcelain(t *testing.T) {
+	input := "diff --git a/f b/f\n" +
+		"--- a/f\n" +
+		"+++ b/f\n" +
+		"@@ -1,4 +1,4 @@\n" +
+		" alpha \n" +
+		"-beta\n" +
+		"+BETA\n" +
+		"  gamma\n" +
+		"~\n" +
+		" same line\n" +
+		"~\n" +
+		"-removed line\n" +
+		"~\n" +
+		"+added line\n" +
+		"~\n"
+
+	result, err := git.ParseDiff("f", input)
+
+	assert.NoError(t, err)
+	lines := result.Hunks[0].Lines
+	assert.Len(t, lines, 4)
+
+	assert.Equal(t, types.LineModified, lines[0].Kind)
+	assert.Equal(t, "alpha BETA gamma", lines[0].Content)
+	assert.Equal(t, 1, lines[0].OldLineNo)
+	assert.Equal(t, 1, lines[0].NewLineNo)
+	assert.Equal(t, []types.WordSegment{
+		{Kind: types.LineContext, Text: "alpha "},
+		{Kind: types.LineDelete, Text: "beta"},
+		{Kind: types.LineAdd, Text: "BETA"},
+		{Kind: types.LineContext, Text: " gamma"},
+	}, lines[0].Segments)
+
+	assert.Equal(t, types.LineContext, lines[1].Kind)
+	assert.Equal(t, 2, lines[1].NewLineNo)
+
+	assert.Equal(t, types.LineDelete, lines[2].Kind)
+	assert.Equal(t, "removed line", lines[2].Content)
+	assert.Equal(t, 3, lines[2].OldLineNo)
+	assert.Equal(t, 0, lines[2].NewLineNo)
+
+	assert.Equal(t, types.LineAdd, lines[3].Kind)
+	assert.Equal(t, 0, lines[3].OldLineNo)
+	assert.Equal(t, 3, lines[3].NewLineNo)
+}
+
+func TestSummarizeDiff(t *testing.T) {
+	input := `diff --git a/a.txt b/a.txt
+--- a/a.txt
++++ b/a.txt
+@@ -1,2 +1,2 @@
+-old
++new
+ same
+@@ -10,1 +10,2 @@
+ ctx
++extra`
+
+	summary := git.SummarizeDiff(input)
+
+	assert.Equal(t, types.DiffSummary{FilesChanged: 1, Insertions: 2, Deletions: 1, HunkCount: 2}, summary)
+}
+
+func TestDiffHeader(t *testing.T) {
+	input := "diff --git a/a b/a\nindex 1..2 100644\n--- a/a\n+++ b/a\n@@ -1 +1 @@\n-x\n+y\n"
+
+	assert.Equal(t, "diff --git a/a b/a\nindex 1..2 100644\n--- a/a\n+++ b/a\n", git.DiffHeader(input))
+	assert.Equal(t, "", git.DiffHeader("@@ -1 +1 @@\n-x\n"))
+}
+
+func TestParsePathList(t *testing.T) {
+	assert.Equal(t, []string{"a.txt", "naïve dir/b.txt"}, git.ParsePathList("a.txt\x00naïve dir/b.txt\x00"))
+	assert.Equal(t, []string{}, git.ParsePathList(""))
+}
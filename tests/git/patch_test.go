@@ -0,0 +1,77 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func patchHunk() types.DiffHunk {
+	return types.DiffHunk{
+		OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3,
+		Lines: []types.DiffLine{
+			{Kind: types.LineContext, Content: "a"},
+			{Kind: types.LineDelete, Content: "b"},
+			{Kind: types.LineDelete, Content: "c"},
+			{Kind: types.LineAdd, Content: "B"},
+			{Kind: types.LineAdd, Content: "C"},
+		},
+	}
+}
+
+func TestFormatPatch(t *testing.T) {
+	hunk := patchHunk()
+	hunk.Lines = append(hunk.Lines, types.DiffLine{Kind: types.LineNoNewline, Content: "No newline at end of file"})
+
+	patch := git.FormatPatch("dir/file.txt", []types.DiffHunk{hunk})
+
+	expected := "diff --git a/dir/file.txt b/dir/file.txt\n" +
+		"--- a/dir/file.txt\n" +
+		"+++ b/dir/file.txt\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" a\n-b\n-c\n+B\n+C\n" +
+		"\\ No newline at end of file\n"
+	assert.Equal(t, expected, patch)
+}
+
+func TestSelectHunkLines(t *testing.T) {
+	hunk, err := git.SelectHunkLines(patchHunk(), []int{1, 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []types.DiffLine{
+		{Kind: types.LineContext, Content: "a"},
+		{Kind: types.LineDelete, Content: "b"},
+		{Kind: types.LineAdd, Content: "B"},
+		{Kind: types.LineContext, Content: "C"},
+	}, hunk.Lines)
+	assert.Equal(t, 3, hunk.OldLines)
+	assert.Equal(t, 3, hunk.NewLines)
+}
+
+func TestSelectHunkLines_DropsNoNewlineOfUnselectedDelete(t *testing.T) {
+	hunk := types.DiffHunk{
+		OldStart: 1, NewStart: 1,
+		Lines: []types.DiffLine{
+			{Kind: types.LineDelete, Content: "old"},
+			{Kind: types.LineNoNewline, Content: "No newline at end of file"},
+			{Kind: types.LineAdd, Content: "new"},
+		},
+	}
+
+	partial, err := git.SelectHunkLines(hunk, []int{2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []types.DiffLine{{Kind: types.LineAdd, Content: "new"}}, partial.Lines)
+	assert.Equal(t, 0, partial.OldLines)
+	assert.Equal(t, 1, partial.NewLines)
+}
+
+func TestSelectHunkLines_OutOfRange(t *testing.T) {
+	_, err := git.SelectHunkLines(patchHunk(), []int{9})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "out of range")
+}
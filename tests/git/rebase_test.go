@@ -0,0 +1,60 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRebaseTodo(t *testing.T) {
+	steps := []types.RebaseStep{
+		{Action: types.RebasePick, SHA: "aaaa111", Subject: "First"},
+		{Action: types.RebaseFixup, SHA: "bbbb222", Subject: "Fix first"},
+		{Action: types.RebaseExec, Command: "make test"},
+		{Action: types.RebaseDrop, SHA: "cccc333", Subject: "WIP"},
+	}
+
+	todo := git.FormatRebaseTodo(steps)
+
+	assert.Equal(t, "pick aaaa111 First\nfixup bbbb222 Fix first\nexec make test\ndrop cccc333 WIP\n", todo)
+}
+
+func TestRebaseMessages(t *testing.T) {
+	steps := []types.RebaseStep{
+		{Action: types.RebaseReword, SHA: "a1", Message: "Better subject"},
+		{Action: types.RebaseSquash, SHA: "b2", Message: "Combined"},
+		{Action: types.RebaseFixup, SHA: "c3"},
+		{Action: types.RebasePick, SHA: "d4"},
+		{Action: types.RebaseFixup, SHA: "e5", Message: "ignored on fixup"},
+		{Action: types.RebaseReword, SHA: "f6"},
+	}
+
+	messages := git.RebaseMessages(steps)
+
+	assert.Equal(t, map[string]string{"a1": "Better subject", "c3": "Combined"}, messages)
+}
+
+func TestParseTodoLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		action types.RebaseAction
+		arg    string
+	}{
+		{"pick 1234abcd Subject line", types.RebasePick, "1234abcd"},
+		{"e 1234abcd Subject", types.RebaseEdit, "1234abcd"},
+		{"fixup -C 1234abcd Subject", types.RebaseFixup, "1234abcd"},
+		{"exec go test ./...", types.RebaseExec, "go test ./..."},
+		{"x make", types.RebaseExec, "make"},
+		{"# comment", "", ""},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		action, arg := git.ParseTodoLine(tt.line)
+		assert.Equal(t, tt.action, action, tt.line)
+		assert.Equal(t, tt.arg, arg, tt.line)
+	}
+}
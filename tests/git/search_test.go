@@ -0,0 +1,64 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchCommits(t *testing.T) {
+	output := "\x1e8937804c0b009744245190990c2b7d42f5c35732\x00Jane\x00jane@example.com\x001700000000\x00Remove parser\x00\x00\n" +
+		"a.go\x00b c.go\x00\n" +
+		"\x1ebce8b4dea72cf6928b7a95bd4ec6b2425ae79f6a\x00John\x00john@example.com\x001690000000\x00Add parser\x00\x00"
+
+	commits := git.ParseSearchCommits(output)
+
+	assert.Equal(t, []types.SearchCommit{
+		{
+			SHA:         "8937804c0b009744245190990c2b7d42f5c35732",
+			Author:      "Jane",
+			AuthorEmail: "jane@example.com",
+			AuthorTime:  1700000000,
+			Subject:     "Remove parser",
+			Files:       []string{"a.go", "b c.go"},
+		},
+		{
+			SHA:         "bce8b4dea72cf6928b7a95bd4ec6b2425ae79f6a",
+			Author:      "John",
+			AuthorEmail: "john@example.com",
+			AuthorTime:  1690000000,
+			Subject:     "Add parser",
+			Files:       []string{},
+		},
+	}, commits)
+}
+
+func TestParseGrep_Context(t *testing.T) {
+	output := "g.txt\x001\x00alpha\n" +
+		"g.txt\x002\x001\x00beta\n" +
+		"g.txt\x003\x00gamma\n" +
+		"g.txt\x004\x00delta\n" +
+		"g.txt\x005\x003\x00z beta\n" +
+		"--\n" +
+		"other.txt\x009\x00before\n" +
+		"other.txt\x0010\x002\x00 beta\n"
+
+	matches := git.ParseGrep(output, "", 1)
+
+	assert.Equal(t, []types.ContentMatch{
+		{Path: "g.txt", LineNumber: 2, Column: 1, Content: "beta", Before: []string{"alpha"}, After: []string{"gamma"}},
+		{Path: "g.txt", LineNumber: 5, Column: 3, Content: "z beta", Before: []string{"delta"}, After: []string{}},
+		{Path: "other.txt", LineNumber: 10, Column: 2, Content: " beta", Before: []string{"before"}, After: []string{}},
+	}, matches)
+}
+
+func TestParseGrep_RevisionPrefix(t *testing.T) {
+	matches := git.ParseGrep("HEAD:dir/a.txt\x003\x001\x00needle\n", "HEAD:", 0)
+
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "dir/a.txt", matches[0].Path)
+	assert.Equal(t, 3, matches[0].LineNumber)
+}
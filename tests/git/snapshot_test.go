@@ -0,0 +1,38 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTreeEntries(t *testing.T) {
+	output := "100644 blob 1111111111111111111111111111111111111111\t0\n" +
+		"120000 blob 2222222222222222222222222222222222222222\t1\n"
+
+	entries := git.ParseTreeEntries(output)
+
+	assert.Equal(t, []git.TreeEntry{
+		{Mode: "100644", Type: "blob", SHA: "1111111111111111111111111111111111111111", Name: "0"},
+		{Mode: "120000", Type: "blob", SHA: "2222222222222222222222222222222222222222", Name: "1"},
+	}, entries)
+}
+
+func TestParseSnapshots(t *testing.T) {
+	output := "200\x001700000200\x00clean\x00a.txt\nsub/b.txt\n\x1e\n" +
+		"100\x001700000100\x00discard c.txt\x00c.txt\n\x1e\n"
+
+	snapshots := git.ParseSnapshots(output)
+
+	assert.Equal(t, []types.Snapshot{
+		{ID: "200", Reason: "clean", CreatedAt: 1700000200, Paths: []string{"a.txt", "sub/b.txt"}},
+		{ID: "100", Reason: "discard c.txt", CreatedAt: 1700000100, Paths: []string{"c.txt"}},
+	}, snapshots)
+}
+
+func TestParseSnapshots_Empty(t *testing.T) {
+	assert.Empty(t, git.ParseSnapshots(""))
+}
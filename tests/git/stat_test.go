@@ -0,0 +1,34 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNumstat(t *testing.T) {
+	output := "-\t-\tbin\x000\t0\t\x00g.txt\x00g2.txt\x00120\t4\tn f.txt\x00"
+
+	stat := git.ParseNumstat(output)
+
+	assert.Equal(t, types.DiffStat{
+		Files: []types.FileStat{
+			{Path: "bin", Binary: true},
+			{Path: "g2.txt", OldPath: "g.txt"},
+			{Path: "n f.txt", Insertions: 120, Deletions: 4},
+		},
+		FilesChanged: 3,
+		Insertions:   120,
+		Deletions:    4,
+	}, stat)
+}
+
+func TestParseNumstat_Empty(t *testing.T) {
+	stat := git.ParseNumstat("")
+
+	assert.Empty(t, stat.Files)
+	assert.Equal(t, 0, stat.FilesChanged)
+}
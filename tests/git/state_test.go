@@ -0,0 +1,127 @@
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeGitFiles creates a fake git directory populated with the given files.
+func writeGitFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestReadHeadState_Branch(t *testing.T) {
+	dir := writeGitFiles(t, map[string]string{
+		"HEAD":            "ref: refs/heads/main\n",
+		"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+	})
+
+	state, err := git.ReadHeadState(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "main", state.Branch)
+	assert.False(t, state.Detached)
+	assert.False(t, state.Unborn)
+	assert.Equal(t, types.OperationNone, state.Operation)
+}
+
+func TestReadHeadState_PackedRef(t *testing.T) {
+	dir := writeGitFiles(t, map[string]string{
+		"HEAD":        "ref: refs/heads/develop\n",
+		"packed-refs": "# pack-refs with: peeled fully-peeled sorted\n0123456789abcdef0123456789abcdef01234567 refs/heads/develop\n",
+	})
+
+	state, err := git.ReadHeadState(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "develop", state.Branch)
+	assert.False(t, state.Unborn)
+}
+
+func TestReadHeadState_Unborn(t *testing.T) {
+	dir := writeGitFiles(t, map[string]string{
+		"HEAD": "ref: refs/heads/main\n",
+	})
+
+	state, err := git.ReadHeadState(dir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "main", state.Branch)
+	assert.True(t, state.Unborn)
+}
+
+func TestReadHeadState_Detached(t *testing.T) {
+	dir := writeGitFiles(t, map[string]string{
+		"HEAD": "abc1234def5678abc1234def5678abc1234def56\n",
+	})
+
+	state, err := git.ReadHeadState(dir)
+
+	assert.NoError(t, err)
+	assert.True(t, state.Detached)
+	assert.Equal(t, "abc1234", state.DetachedAt)
+	assert.Equal(t, "", state.Branch)
+}
+
+func TestReadHeadState_RebaseInProgress(t *testing.T) {
+	dir := writeGitFiles(t, map[string]string{
+		"HEAD":                   "abc1234def5678abc1234def5678abc1234def56\n",
+		"rebase-merge/head-name": "refs/heads/feature/login\n",
+		"rebase-merge/msgnum":    "3\n",
+		"rebase-merge/end":       "7\n",
+	})
+
+	state, err := git.ReadHeadState(dir)
+
+	assert.NoError(t, err)
+	assert.True(t, state.Detached)
+	assert.Equal(t, "feature/login", state.Branch)
+	assert.Equal(t, types.OperationRebase, state.Operation)
+	assert.Equal(t, 3, state.Step)
+	assert.Equal(t, 7, state.TotalSteps)
+}
+
+func TestReadHeadState_Operations(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected types.RepoOperation
+	}{
+		{"MERGE_HEAD", types.OperationMerge},
+		{"CHERRY_PICK_HEAD", types.OperationCherryPick},
+		{"REVERT_HEAD", types.OperationRevert},
+		{"BISECT_LOG", types.OperationBisect},
+	}
+
+	for _, tt := range tests {
+		dir := writeGitFiles(t, map[string]string{
+			"HEAD":            "ref: refs/heads/main\n",
+			"refs/heads/main": "0123456789abcdef0123456789abcdef01234567\n",
+			tt.file:           "0123456789abcdef0123456789abcdef01234567\n",
+		})
+
+		state, err := git.ReadHeadState(dir)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, state.Operation, tt.file)
+	}
+}
+
+func TestReadHeadState_MissingHead(t *testing.T) {
+	_, err := git.ReadHeadState(t.TempDir())
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package git_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	output := "v1\x00tag\x003bd581e6ee5926e21a750675ab112bdfc85047d7\x0046ccf5930e2fa543e3dc32ef3ec3d4dfd684f932\x00" +
+		"Jane\x00jane@example.com\x001792358708\x00Release 1\x00Notes here\n\x00\x1e\n" +
+		"light\x00commit\x0033bc970f93aec8fc95826e7c72948320533e4f033\x00\x00\x00\x001792358593\x00main\x00\x00\x1e\n"
+
+	tags := git.ParseTags(output)
+
+	assert.Equal(t, []types.Tag{
+		{
+			Name:        "v1",
+			Target:      "46ccf5930e2fa543e3dc32ef3ec3d4dfd684f932",
+			Annotated:   true,
+			Tagger:      "Jane",
+			TaggerEmail: "jane@example.com",
+			Date:        1792358708,
+			Message:     "Release 1\n\nNotes here",
+		},
+		{Name: "light", Target: "33bc970f93aec8fc95826e7c72948320533e4f033", Date: 1792358593},
+	}, tags)
+}
+
+func TestParseTags_Signed(t *testing.T) {
+	output := "v2\x00tag\x00aaaa\x00bbbb\x00Jane\x00jane@example.com\x001\x00Release 2\x00\x00" +
+		"-----BEGIN PGP SIGNATURE-----\n-----END PGP SIGNATURE-----\n\x1e\n"
+
+	tags := git.ParseTags(output)
+
+	assert.Len(t, tags, 1)
+	assert.True(t, tags[0].Signed)
+	assert.Equal(t, "Release 2", tags[0].Message)
+}
+
+func TestParseTags_Empty(t *testing.T) {
+	assert.Empty(t, git.ParseTags(""))
+}
@@ -0,0 +1,107 @@
+package graph_test
+
+import (
+	"testing"
+
+	"git-gui/backend/graph"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func commit(sha string, parents ...string) types.GraphCommit {
+	return types.GraphCommit{SHA: sha, Parents: parents}
+}
+
+// forkAndMerge is M merging feature branch F back into C, with both
+// branches starting at B.
+func forkAndMerge() []types.GraphCommit {
+	return []types.GraphCommit{
+		commit("M", "C", "F"),
+		commit("C", "B"),
+		commit("F", "B"),
+		commit("B", "A"),
+		commit("A"),
+	}
+}
+
+func TestLayout_Linear(t *testing.T) {
+	rows := graph.New().Add([]types.GraphCommit{commit("C", "B"), commit("B", "A"), commit("A")})
+
+	assert.Len(t, rows, 3)
+	assert.Empty(t, rows[0].Edges)
+	for _, row := range rows {
+		assert.Equal(t, 0, row.Column)
+		assert.Equal(t, 1, row.Width)
+		assert.False(t, row.Merge)
+		assert.False(t, row.Fork)
+	}
+	assert.Equal(t, []types.GraphEdge{{From: 0, To: 0, Color: 0}}, rows[2].Edges)
+}
+
+func TestLayout_ForkAndMerge(t *testing.T) {
+	rows := graph.New().Add(forkAndMerge())
+
+	assert.True(t, rows[0].Merge)
+	assert.Equal(t, 0, rows[0].Column)
+
+	assert.Equal(t, 0, rows[1].Column)
+	assert.Equal(t, []types.GraphEdge{{From: 0, To: 0, Color: 0}, {From: 0, To: 1, Color: 1}}, rows[1].Edges)
+	assert.Equal(t, 2, rows[1].Width)
+
+	assert.Equal(t, 1, rows[2].Column)
+	assert.Equal(t, 1, rows[2].Color)
+
+	assert.Equal(t, 0, rows[3].Column)
+	assert.True(t, rows[3].Fork)
+	assert.Equal(t, []types.GraphEdge{{From: 0, To: 0, Color: 0}, {From: 1, To: 0, Color: 1}}, rows[3].Edges)
+
+	assert.Equal(t, []types.GraphEdge{{From: 0, To: 0, Color: 0}}, rows[4].Edges)
+	assert.Equal(t, 1, rows[4].Width)
+}
+
+func TestLayout_MergeJoinsExistingLane(t *testing.T) {
+	// Both merges bring in F, so the second merge line joins F's lane.
+	rows := graph.New().Add([]types.GraphCommit{
+		commit("M2", "M1", "F"),
+		commit("M1", "B", "F"),
+		commit("F", "B"),
+		commit("B"),
+	})
+
+	assert.Equal(t, []types.GraphEdge{{From: 0, To: 0, Color: 0}, {From: 0, To: 1, Color: 1}}, rows[1].Edges)
+	assert.Equal(t, []types.GraphEdge{{From: 0, To: 0, Color: 0}, {From: 1, To: 1, Color: 1}, {From: 0, To: 1, Color: 1}}, rows[2].Edges)
+	assert.True(t, rows[2].Fork)
+	assert.Equal(t, 2, len(rows[3].Edges))
+}
+
+func TestLayout_ReusesFreedColumns(t *testing.T) {
+	rows := graph.New().Add([]types.GraphCommit{
+		commit("X", "A"),
+		commit("Y", "B"),
+		commit("A"),
+		commit("Z", "B"),
+		commit("B"),
+	})
+
+	assert.Equal(t, 0, rows[0].Column)
+	assert.Equal(t, 1, rows[1].Column)
+	assert.Equal(t, 0, rows[2].Column)
+	// A's lane ended, so the next branch tip takes its column.
+	assert.Equal(t, 0, rows[3].Column)
+	assert.Equal(t, 0, rows[4].Column)
+	assert.Equal(t, []types.GraphEdge{{From: 0, To: 0, Color: 2}, {From: 1, To: 0, Color: 1}}, rows[4].Edges)
+	assert.True(t, rows[4].Fork)
+}
+
+func TestLayout_Paginated(t *testing.T) {
+	whole := graph.New().Add(forkAndMerge())
+
+	layout := graph.New()
+	first := layout.Add(forkAndMerge()[:2])
+	assert.Equal(t, 2, layout.Len())
+	second := layout.Add(forkAndMerge()[2:])
+
+	assert.Equal(t, whole, append(first, second...))
+	assert.Equal(t, 5, layout.Len())
+}
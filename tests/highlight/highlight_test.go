@@ -0,0 +1,69 @@
+package highlight_test
+
+import (
+	"testing"
+
+	"git-gui/backend/git"
+	"git-gui/backend/highlight"
+	"git-gui/backend/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		path     string
+		linguist string
+		expected string
+	}{
+		{"main.go", "", "Go"},
+		{"scripts/build.py", "", "Python"},
+		{"config.inc", "PHP", "PHP"},
+		{"README.unknownext", "", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, highlight.DetectLanguage(tt.path, tt.linguist), tt.path)
+	}
+}
+
+func TestTokenizeLines_MultiLineComment(t *testing.T) {
+	source := "/* start\nstill comment */\nx := 1\n"
+
+	lines, err := highlight.TokenizeLines("Go", source)
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(lines), 3)
+	assert.Equal(t, []types.TokenSpan{{Start: 0, End: 16, Class: "cm"}}, lines[1])
+	assert.Contains(t, lines[2], types.TokenSpan{Start: 0, End: 1, Class: "nx"})
+}
+
+func TestTokenizeLines_UnknownLanguage(t *testing.T) {
+	lines, err := highlight.TokenizeLines("", "anything")
+
+	assert.NoError(t, err)
+	assert.Nil(t, lines)
+}
+
+func TestHighlightDiff_UsesMatchingSide(t *testing.T) {
+	diff := `@@ -1,2 +1,2 @@
+ package main
+-var a = "old"
++var a = 2`
+	result, err := git.ParseDiff("main.go", diff)
+	assert.NoError(t, err)
+
+	highlighted, err := highlight.HighlightDiff(result, "Go",
+		"package main\nvar a = \"old\"\n",
+		"package main\nvar a = 2\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", highlighted.Language)
+	assert.Len(t, highlighted.Hunks, 1)
+
+	lines := highlighted.Hunks[0].Lines
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[0].Tokens, types.TokenSpan{Start: 0, End: 7, Class: "kn"})
+	assert.Contains(t, lines[1].Tokens, types.TokenSpan{Start: 8, End: 13, Class: "s"})
+	assert.Contains(t, lines[2].Tokens, types.TokenSpan{Start: 8, End: 9, Class: "mi"})
+}
@@ -0,0 +1,96 @@
+package rebase_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git-gui/backend/rebase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPlan writes plan into a fake git directory whose rebase has processed
+// the done lines given.
+func newPlan(t *testing.T, plan rebase.Plan, done string) string {
+	t.Helper()
+	gitDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(gitDir, "rebase-merge"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(gitDir, "rebase-merge", "done"), []byte(done), 0o644))
+	planPath := filepath.Join(gitDir, rebase.PlanFile)
+	require.NoError(t, rebase.WritePlan(planPath, plan))
+	return planPath
+}
+
+func TestRunEditor_Sequence(t *testing.T) {
+	planPath := newPlan(t, rebase.Plan{Todo: "drop abc1234 WIP\n"}, "")
+	todo := filepath.Join(t.TempDir(), "git-rebase-todo")
+	require.NoError(t, os.WriteFile(todo, []byte("pick abc1234 WIP\n"), 0o644))
+
+	err := rebase.RunEditor([]string{"sequence", planPath, todo})
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(todo)
+	assert.Equal(t, "drop abc1234 WIP\n", string(content))
+}
+
+func TestRunEditor_MessageForCurrentStep(t *testing.T) {
+	planPath := newPlan(t, rebase.Plan{Messages: map[string]string{
+		"abc1234def": "New message",
+	}}, "pick 1111111 First\nreword abc1234 Old\n")
+	message := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	require.NoError(t, os.WriteFile(message, []byte("Old\n"), 0o644))
+
+	err := rebase.RunEditor([]string{"message", planPath, message})
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(message)
+	assert.Equal(t, "New message\n", string(content))
+}
+
+func TestRunEditor_MessageLeftUnchanged(t *testing.T) {
+	planPath := newPlan(t, rebase.Plan{Messages: map[string]string{"abc1234": "New"}}, "pick 2222222 Other\n")
+	message := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	require.NoError(t, os.WriteFile(message, []byte("Keep me\n"), 0o644))
+
+	err := rebase.RunEditor([]string{"message", planPath, message})
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(message)
+	assert.Equal(t, "Keep me\n", string(content))
+}
+
+func TestRunEditor_MissingPlan(t *testing.T) {
+	message := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	require.NoError(t, os.WriteFile(message, []byte("Keep me\n"), 0o644))
+
+	err := rebase.RunEditor([]string{"message", filepath.Join(t.TempDir(), rebase.PlanFile), message})
+
+	assert.NoError(t, err)
+	content, _ := os.ReadFile(message)
+	assert.Equal(t, "Keep me\n", string(content))
+}
+
+func TestRunEditor_BadArguments(t *testing.T) {
+	err := rebase.RunEditor([]string{"sequence"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "usage")
+}
+
+func TestEditorEnv(t *testing.T) {
+	env := rebase.EditorEnv("/opt/Git GUI/it's", "/repo/.git/gitgui-rebase.json")
+
+	assert.Equal(t, []string{
+		`GIT_SEQUENCE_EDITOR='/opt/Git GUI/it'\''s' --rebase-editor sequence '/repo/.git/gitgui-rebase.json'`,
+		`GIT_EDITOR='/opt/Git GUI/it'\''s' --rebase-editor message '/repo/.git/gitgui-rebase.json'`,
+	}, env)
+}
+
+func TestCurrentStep(t *testing.T) {
+	planPath := newPlan(t, rebase.Plan{}, "pick 1111111 First\nedit 2222222 Second\n")
+
+	assert.Equal(t, "edit 2222222 Second", rebase.CurrentStep(filepath.Dir(planPath)))
+	assert.Equal(t, "", rebase.CurrentStep(t.TempDir()))
+}